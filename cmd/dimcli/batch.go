@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"gopkg.in/yaml.v3"
+)
+
+// batchCall is an entry of the batch file
+type batchCall struct {
+	Func string `json:"func" yaml:"func"`
+	Args []any  `json:"args" yaml:"args"`
+}
+
+// batchResult is the report of a batch call
+type batchResult struct {
+	Call   int    `json:"call"`
+	Func   string `json:"func"`
+	Status string `json:"status"` // one of "ok", "error", "skipped", "dry-run"
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// batch runs the DIM function calls of the file over one session
+func batch(c *cli, cmd *command, args []string) error {
+	fs := c.flagSet(cmd)
+	continueOnError := fs.Bool("continue-on-error", false, "run the remaining calls after a failed one")
+	dryRun := fs.Bool("dry-run", false, "only check the file, do not call DIM")
+	positional, err := c.parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	calls, err := readBatch(positional[0])
+	if err != nil {
+		return usageErrorf("could not read batch file: %s", err)
+	}
+
+	results := make([]batchResult, len(calls))
+	for i, call := range calls {
+		results[i] = batchResult{Call: i + 1, Func: call.Func, Status: "skipped"}
+	}
+
+	var dimC *dim.Client
+	if !*dryRun && len(calls) > 0 {
+		if dimC, err = c.dimClient(); err != nil {
+			return err
+		}
+	}
+
+	var failed []error
+	for i, call := range calls {
+		if *dryRun {
+			results[i].Status = "dry-run"
+			continue
+		}
+		if len(failed) > 0 && !*continueOnError {
+			break
+		}
+		res, err := dimC.RawCallWithContext(c.ctx, call.Func, call.Args)
+		if err != nil {
+			results[i].Status, results[i].Error = "error", err.Error()
+			failed = append(failed, err)
+			continue
+		}
+		results[i].Status, results[i].Result = "ok", res
+	}
+
+	t := &table{header: []string{"CALL", "FUNC", "STATUS", "RESULT"}}
+	for _, r := range results {
+		result := r.Error
+		if r.Result != nil {
+			result = cell(r.Result)
+		}
+		t.rows = append(t.rows, []string{strconv.Itoa(r.Call), r.Func, r.Status, result})
+	}
+	if err := c.print(results, t); err != nil {
+		return err
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+	// the exit code is the one of a DIM error only if all failures are DIM errors
+	var dimErr dim.Error
+	for _, err := range failed {
+		if !errors.As(err, &dimErr) {
+			return fmt.Errorf("%d of %d calls failed, first: %s", len(failed), len(calls), failed[0])
+		}
+	}
+	return fmt.Errorf("%d of %d calls failed, first: %w", len(failed), len(calls), failed[0])
+}
+
+// readBatch reads the calls from the file, or from stdin if name is "-".
+// The file is a YAML or JSON list of calls, or JSON lines with a call per line.
+func readBatch(name string) ([]batchCall, error) {
+	var data []byte
+	var err error
+	if name == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var calls []batchCall
+	ext := filepath.Ext(name)
+	if ext == ".jsonl" || ext == ".ndjson" || bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.DisallowUnknownFields()
+		d.UseNumber() // keep the integers above 2^53 exact
+		for {
+			var call batchCall
+			if err := d.Decode(&call); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("call %d: %w", len(calls)+1, err)
+			}
+			calls = append(calls, call)
+		}
+	} else {
+		// YAML is a superset of JSON, so this reads a JSON list as well
+		d := yaml.NewDecoder(bytes.NewReader(data))
+		d.KnownFields(true)
+		if err := d.Decode(&calls); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+	}
+
+	for i := range calls {
+		if calls[i].Func == "" {
+			return nil, fmt.Errorf("call %d: func must be specified", i+1)
+		}
+		if calls[i].Args == nil {
+			calls[i].Args = []any{}
+		}
+	}
+	return calls, nil
+}
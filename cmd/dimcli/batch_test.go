@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadBatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wants    []batchCall
+		wantsErr bool
+	}{
+		{
+			name: "calls.yaml",
+			content: "- func: ippool_get_ip\n  args: [some-pool, {attributes: {comment: first}}]\n" +
+				"- func: layer3domain_list\n",
+			wants: []batchCall{
+				{Func: "ippool_get_ip", Args: []any{"some-pool", map[string]any{"attributes": map[string]any{"comment": "first"}}}},
+				{Func: "layer3domain_list", Args: []any{}},
+			},
+		},
+		{
+			name:    "calls.json",
+			content: `[{"func": "ip_free", "args": ["10.0.0.1", {"host": true}]}, {"func": "layer3domain_list"}]`,
+			wants: []batchCall{
+				{Func: "ip_free", Args: []any{"10.0.0.1", map[string]any{"host": true}}},
+				{Func: "layer3domain_list", Args: []any{}},
+			},
+		},
+		{
+			name:    "calls.jsonl",
+			content: `{"func": "ip_free", "args": ["10.0.0.1", {"host": true}]}` + "\n\n" + `{"func": "layer3domain_list", "args": []}` + "\n",
+			wants: []batchCall{
+				{Func: "ip_free", Args: []any{"10.0.0.1", map[string]any{"host": true}}},
+				{Func: "layer3domain_list", Args: []any{}},
+			},
+		},
+		// JSON lines are recognized by the content as well
+		{
+			name:    "calls.txt",
+			content: ` {"func": "layer3domain_list"}` + "\n" + `{"func": "zone_list"}`,
+			wants:   []batchCall{{Func: "layer3domain_list", Args: []any{}}, {Func: "zone_list", Args: []any{}}},
+		},
+		// the integers above 2^53 are not rounded to float64
+		{
+			name:    "big.ndjson",
+			content: `{"func": "rr_create", "args": [{"ttl": 9007199254740993, "weight": 0.5}]}`,
+			wants:   []batchCall{{Func: "rr_create", Args: []any{map[string]any{"ttl": json.Number("9007199254740993"), "weight": json.Number("0.5")}}}},
+		},
+		{
+			name:    "big.json",
+			content: `[{"func": "rr_create", "args": [{"ttl": 9007199254740993}]}]`,
+			wants:   []batchCall{{Func: "rr_create", Args: []any{map[string]any{"ttl": 9007199254740993}}}},
+		},
+		{name: "empty.yaml", content: "", wants: nil},
+		{name: "nofunc.yaml", content: "- args: []\n", wantsErr: true},
+		{name: "nofunc.jsonl", content: `{"func": "zone_list"}` + "\n" + `{"args": []}`, wantsErr: true},
+		{name: "unknown.yaml", content: "- func: zone_list\n  arg: []\n", wantsErr: true},
+		{name: "unknown.jsonl", content: `{"func": "zone_list", "arg": []}`, wantsErr: true},
+		{name: "invalid.jsonl", content: `{"func": "zone_list"`, wantsErr: true},
+		{name: "notalist.yaml", content: "func: zone_list\n", wantsErr: true},
+	}
+
+	dir := t.TempDir()
+	for i := 0; i < len(tests); i++ {
+		name := filepath.Join(dir, tests[i].name)
+		if err := os.WriteFile(name, []byte(tests[i].content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		got, err := readBatch(name)
+		if tests[i].wantsErr {
+			if err == nil {
+				t.Errorf("readBatch(%q) = %#v ; wants error", tests[i].name, got)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tests[i].wants) {
+			t.Errorf("readBatch(%q) = %#v, %v ; wants = %#v", tests[i].name, got, err, tests[i].wants)
+		}
+	}
+}
+
+func TestReadBatchStdin(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(name, []byte(`{"func": "zone_list"}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	stdin := os.Stdin
+	os.Stdin = f
+	defer func() { os.Stdin = stdin }()
+
+	got, err := readBatch("-")
+	wants := []batchCall{{Func: "zone_list", Args: []any{}}}
+	if err != nil || !reflect.DeepEqual(got, wants) {
+		t.Errorf("readBatch(%q) = %#v, %v ; wants = %#v", "-", got, err, wants)
+	}
+}
+
+// brokenEndpoint returns the endpoint forwarding to the server at target,
+// except the calls of the function "broken" which fail with http status 500
+func brokenEndpoint(t *testing.T, target string) string {
+	t.Helper()
+	u, err := url.Parse(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := httputil.NewSingleHostReverseProxy(u)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if bytes.Contains(body, []byte(`"method":"broken"`)) {
+			http.Error(w, "broken", http.StatusInternalServerError)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		proxy.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestBatch(t *testing.T) {
+	const calls = `
+- func: ippool_get_ip
+  args: [some-pool]
+- func: ip_mark
+  args: ["10.88.8.7", {pool: some-pool, host: true}]
+- func: broken
+- func: ippool_get_ip
+  args: [some-pool]
+`
+	tests := []struct {
+		args []string
+
+		wantsStatus []string
+		wantsCode   int
+	}{
+		{args: []string{"-dry-run"}, wantsStatus: []string{"dry-run", "dry-run", "dry-run", "dry-run"}, wantsCode: exitOK},
+		// the DIM error stops the batch
+		{args: nil, wantsStatus: []string{"ok", "error", "skipped", "skipped"}, wantsCode: exitDimError},
+		// a failure other than a DIM error is not reported as one
+		{args: []string{"-continue-on-error"}, wantsStatus: []string{"ok", "error", "error", "ok"}, wantsCode: exitFailure},
+	}
+
+	file := filepath.Join(t.TempDir(), "calls.yaml")
+	if err := os.WriteFile(file, []byte(calls), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < len(tests); i++ {
+		s, c, out := newTestCLI(t)
+		if err := s.AddPool("some-pool", "10.88.8.0/29"); err != nil {
+			t.Fatal(err)
+		}
+		c.endpoint = brokenEndpoint(t, s.URL)
+		c.output = "json"
+
+		err := run(c, append(append([]string{"batch"}, tests[i].args...), file)...)
+		if code := c.exitCode(err); code != tests[i].wantsCode {
+			t.Errorf("batch %q exit code = %d (%v) ; wants = %d", tests[i].args, code, err, tests[i].wantsCode)
+		}
+		var results []batchResult
+		if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+			t.Fatalf("batch %q output %q: %s", tests[i].args, out, err)
+		}
+		var status []string
+		for _, r := range results {
+			status = append(status, r.Status)
+		}
+		if !reflect.DeepEqual(status, tests[i].wantsStatus) {
+			t.Errorf("batch %q status = %q ; wants = %q", tests[i].args, status, tests[i].wantsStatus)
+		}
+	}
+}
+
+func TestBatchAllDimErrors(t *testing.T) {
+	s, c, out := newTestCLI(t)
+	if err := s.AddPool("some-pool", "10.88.8.0/30"); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "calls.jsonl")
+	calls := `{"func": "ip_mark", "args": ["10.88.8.3", {"pool": "some-pool", "host": true}]}
+{"func": "ippool_get_ip", "args": ["some-pool"]}
+{"func": "ippool_get_ip", "args": ["unknown-pool"]}
+`
+	if err := os.WriteFile(file, []byte(calls), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	err := run(c, "batch", "-continue-on-error", file)
+	if code := c.exitCode(err); code != exitDimError {
+		t.Errorf("batch exit code = %d (%v) ; wants = %d", code, err, exitDimError)
+	}
+	var status []string
+	for _, line := range bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n")) {
+		status = append(status, string(bytes.Fields(line)[2]))
+	}
+	wants := []string{"STATUS", "error", "ok", "error"}
+	if !reflect.DeepEqual(status, wants) || !bytes.Contains(out.Bytes(), []byte(`"ip":"10.88.8.2"`)) {
+		t.Errorf("batch = %q ; wants status %q", out, wants)
+	}
+}
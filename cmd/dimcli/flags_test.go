@@ -0,0 +1,92 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMapFlag(t *testing.T) {
+	tests := []struct {
+		values   []string
+		wants    mapFlag
+		wantsErr bool
+	}{
+		{values: []string{"owner=network"}, wants: mapFlag{"owner": "network"}},
+		{values: []string{"a=1", "b=2", "a=3"}, wants: mapFlag{"a": "3", "b": "2"}},
+		{values: []string{"empty="}, wants: mapFlag{"empty": ""}},
+		{values: []string{"url=http://host/?a=b"}, wants: mapFlag{"url": "http://host/?a=b"}},
+		{values: []string{"owner"}, wantsErr: true},
+		{values: []string{"=network"}, wantsErr: true},
+		{values: []string{""}, wantsErr: true},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		f := mapFlag{}
+		var err error
+		for _, v := range tests[i].values {
+			if err = f.Set(v); err != nil {
+				break
+			}
+		}
+		if tests[i].wantsErr {
+			if err == nil {
+				t.Errorf("mapFlag.Set(%q) = %q ; wants error", tests[i].values, f)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(f, tests[i].wants) {
+			t.Errorf("mapFlag.Set(%q) = %q, %v ; wants = %q", tests[i].values, f, err, tests[i].wants)
+		}
+	}
+
+	if got := (mapFlag{"owner": "network"}).String(); got != "owner=network" {
+		t.Errorf("mapFlag.String() = %q ; wants = %q", got, "owner=network")
+	}
+}
+
+func TestInt64Flag(t *testing.T) {
+	tests := []struct {
+		value    string
+		wants    int64
+		wantsErr bool
+	}{
+		{value: "0", wants: 0},
+		{value: "3600", wants: 3600},
+		{value: "-1", wants: -1},
+		{value: "9007199254740993", wants: 9007199254740993},
+		{value: "", wantsErr: true},
+		{value: "1h", wantsErr: true},
+		{value: "1.5", wantsErr: true},
+		{value: "9223372036854775808", wantsErr: true},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		var f int64Flag
+		err := f.Set(tests[i].value)
+		if tests[i].wantsErr {
+			if err == nil || f.v != nil {
+				t.Errorf("int64Flag.Set(%q) = %q ; wants error", tests[i].value, f.String())
+			}
+			continue
+		}
+		if err != nil || f.v == nil || *f.v != tests[i].wants || f.String() != tests[i].value {
+			t.Errorf("int64Flag.Set(%q) = %q, %v ; wants = %d", tests[i].value, f.String(), err, tests[i].wants)
+		}
+	}
+
+	var unset int64Flag
+	if unset.v != nil || unset.String() != "" {
+		t.Errorf("int64Flag{}.String() = %q ; wants = %q", unset.String(), "")
+	}
+}
+
+func TestStringFlag(t *testing.T) {
+	var f stringFlag
+	if f.v != nil || f.String() != "" {
+		t.Errorf("stringFlag{}.String() = %q ; wants unset", f.String())
+	}
+	// an empty value is set, e.g. to clear a comment
+	if err := f.Set(""); err != nil || f.v == nil || *f.v != "" {
+		t.Errorf("stringFlag.Set(%q) = %v, %v ; wants set", "", f.v, err)
+	}
+}
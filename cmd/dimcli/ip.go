@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+
+	"terraform-provider-ionosdim/pkg/dim"
+)
+
+// ipGet prints the attributes of the ip address
+func ipGet(c *cli, cmd *command, args []string) error {
+	fs := c.flagSet(cmd)
+	layer3domain := fs.String("layer3domain", "", "layer3domain of the ip address")
+	positional, err := c.parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	dimC, err := c.dimClient()
+	if err != nil {
+		return err
+	}
+	attrs, err := dimC.IPBlockGetAttrs(c.ctx, positional[0], dim.IPBlockOptions{
+		Host:         true,
+		Layer3domain: *layer3domain,
+	})
+	if err != nil {
+		return err
+	}
+	return c.printIP(attrs)
+}
+
+// ipAllocate allocates the next free ip address of the pool,
+// or the given ip address if -ip is set
+func ipAllocate(c *cli, cmd *command, args []string) error {
+	fs := c.flagSet(cmd)
+	pool := fs.String("pool", "", "pool to allocate the ip address from (required)")
+	ip := fs.String("ip", "", "ip address to allocate instead of the next free one")
+	var comment stringFlag
+	fs.Var(&comment, "comment", "comment of the ip address")
+	attributes := mapFlag{}
+	fs.Var(attributes, "attr", "user defined attribute as key=value, may be repeated")
+	if _, err := c.parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	if *pool == "" {
+		fs.Usage()
+		return usageErrorf("-pool must be specified")
+	}
+	if comment.v != nil {
+		attributes["comment"] = *comment.v
+	}
+
+	dimC, err := c.dimClient()
+	if err != nil {
+		return err
+	}
+	var attrs *dim.IPBlockAttrs
+	if *ip == "" {
+		attrs, err = dimC.IPPoolGetIP(c.ctx, *pool, dim.IPPoolGetIPOptions{
+			Attributes: attributes,
+		})
+	} else {
+		attrs, err = dimC.IPMark(c.ctx, *ip, dim.IPMarkOptions{
+			IPBlockOptions: dim.IPBlockOptions{
+				Pool: *pool,
+				Host: true,
+			},
+			Attributes: attributes,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	return c.printIP(attrs)
+}
+
+// ipFree frees the ip address
+func ipFree(c *cli, cmd *command, args []string) error {
+	fs := c.flagSet(cmd)
+	pool := fs.String("pool", "", "free the ip address only if it belongs to the pool")
+	layer3domain := fs.String("layer3domain", "", "layer3domain of the ip address")
+	positional, err := c.parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	dimC, err := c.dimClient()
+	if err != nil {
+		return err
+	}
+	ip := positional[0]
+	res, err := dimC.IPFree(c.ctx, ip, dim.IPBlockOptions{
+		Host:         true,
+		Layer3domain: *layer3domain,
+		Pool:         *pool,
+	})
+	if err != nil {
+		return err
+	}
+
+	// ip_free returns 1 if the address was freed, 0 if it was free already
+	// and -1 if it is reserved, e.g. the network or broadcast address
+	var status string
+	switch res {
+	case 1:
+		status = "freed"
+	case 0:
+		status = "already free"
+	case -1:
+		return fmt.Errorf("%s is reserved and was not freed", ip)
+	default:
+		return fmt.Errorf("unexpected ip_free result %d for %s", res, ip)
+	}
+	return c.print(map[string]string{"ip": ip, "result": status}, nil)
+}
+
+func (c *cli) printIP(attrs *dim.IPBlockAttrs) error {
+	v, err := withAttributes(attrs, attrs.Attributes)
+	if err != nil {
+		return err
+	}
+	return c.print(v, nil)
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+// savedSession is the login saved by dimcli login
+type savedSession struct {
+	Endpoint  string    `yaml:"endpoint"`
+	Username  string    `yaml:"username"`
+	TokenFile string    `yaml:"token_file"`
+	LoggedIn  time.Time `yaml:"logged_in"`
+}
+
+// configDir returns the directory of the saved session and the default token file,
+// e.g. ~/.config/ionosdim on Linux
+func configDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ionosdim"), nil
+}
+
+func sessionFile() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "session.yaml"), nil
+}
+
+// loadSession returns the saved session, or nil if there is none
+func loadSession() (*savedSession, error) {
+	name, err := sessionFile()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s savedSession
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", name, err)
+	}
+	return &s, nil
+}
+
+// writePrivateFile writes the file readable by the user only
+func writePrivateFile(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(name, data, 0o600); err != nil {
+		return err
+	}
+	// WriteFile keeps the permissions of an existing file
+	return os.Chmod(name, 0o600)
+}
+
+func readToken(name string) (string, error) {
+	token, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+// loadToken reads the token from the -token file. If neither the -token file
+// nor IONOSDIM_TOKEN is given, the token saved by dimcli login is used
+// unless it was obtained from another endpoint.
+func (c *cli) loadToken() error {
+	if c.tokenFile != "" {
+		token, err := readToken(c.tokenFile)
+		if err != nil {
+			return usageErrorf("could not read dim token file: %s", err)
+		}
+		c.token = token
+		return nil
+	}
+	if c.token != "" {
+		return nil
+	}
+	s, err := loadSession()
+	if err != nil || s == nil {
+		return err
+	}
+	if c.endpoint != "" && c.endpoint != s.Endpoint {
+		return nil
+	}
+	token, err := readToken(s.TokenFile)
+	if err != nil {
+		return fmt.Errorf("could not read the saved dim token file: %w", err)
+	}
+	c.endpoint, c.token, c.session = s.Endpoint, token, s
+	return nil
+}
+
+// login logs in to DIM and saves the session token
+func login(c *cli, cmd *command, args []string) error {
+	fs := c.flagSet(cmd)
+	username := fs.String("username", c.username, "DIM username, prompted for if not set")
+	if _, err := c.parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	if c.endpoint == "" {
+		if s, err := loadSession(); err == nil && s != nil {
+			c.endpoint = s.Endpoint
+		}
+	}
+	if c.endpoint == "" {
+		return usageErrorf("DIM endpoint must be specified. Set the endpoint value as command line argument or use the IONOSDIM_ENDPOINT environment variable.")
+	}
+
+	stdin := bufio.NewReader(os.Stdin)
+	if *username == "" {
+		fmt.Fprint(os.Stderr, "Username: ")
+		line, err := readLine(stdin)
+		if err != nil {
+			return err
+		}
+		*username = line
+	}
+	password := c.password
+	if password == "" {
+		fmt.Fprintf(os.Stderr, "Password for %s: ", *username)
+		var err error
+		if password, err = readPassword(stdin); err != nil {
+			return err
+		}
+	}
+
+	// always login, even if a token is known
+	token := ""
+	dimC, err := dim.NewClientWithContext(c.ctx, &c.endpoint, &token, username, &password, nil, dim.WithTransport(c.transport))
+	if err != nil {
+		return err
+	}
+
+	s := savedSession{
+		Endpoint:  c.endpoint,
+		Username:  *username,
+		TokenFile: c.tokenFile,
+		LoggedIn:  time.Now().UTC().Truncate(time.Second),
+	}
+	if s.TokenFile == "" {
+		dir, err := configDir()
+		if err != nil {
+			return err
+		}
+		s.TokenFile = filepath.Join(dir, "token")
+	}
+	if s.TokenFile, err = filepath.Abs(s.TokenFile); err != nil {
+		return err
+	}
+	if err := writePrivateFile(s.TokenFile, []byte(dimC.Token()+"\n")); err != nil {
+		return err
+	}
+	name, err := sessionFile()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := writePrivateFile(name, data); err != nil {
+		return err
+	}
+	return c.print(s.fields(), nil)
+}
+
+// logout ends the DIM session and removes the saved token
+func logout(c *cli, cmd *command, args []string) error {
+	fs := c.flagSet(cmd)
+	if _, err := c.parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	s, err := loadSession()
+	if err != nil {
+		return err
+	}
+	if s == nil && c.tokenFile == "" {
+		return usageErrorf("not logged in, no saved session found")
+	}
+	if s != nil {
+		if c.tokenFile == "" {
+			c.tokenFile = s.TokenFile
+		}
+		if c.endpoint == "" {
+			c.endpoint = s.Endpoint
+		}
+	}
+	if c.tokenFile, err = filepath.Abs(c.tokenFile); err != nil {
+		return err
+	}
+	if c.endpoint == "" {
+		return usageErrorf("DIM endpoint must be specified. Set the endpoint value as command line argument or use the IONOSDIM_ENDPOINT environment variable.")
+	}
+	token, err := readToken(c.tokenFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// the saved files are removed even if DIM could not be reached
+	var logoutErr error
+	if token != "" {
+		noUser := ""
+		dimC, err := dim.NewClientWithContext(c.ctx, &c.endpoint, &token, &noUser, &noUser, nil, dim.WithTransport(c.transport))
+		if err != nil {
+			return err
+		}
+		logoutErr = dimC.Logout(c.ctx)
+	}
+	if err := os.Remove(c.tokenFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if s != nil && s.TokenFile == c.tokenFile {
+		name, err := sessionFile()
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(name); err != nil {
+			return err
+		}
+	}
+	if logoutErr != nil {
+		return fmt.Errorf("removed %s, but could not logout from DIM: %w", c.tokenFile, logoutErr)
+	}
+	return c.print(map[string]string{"endpoint": c.endpoint, "token_file": c.tokenFile, "result": "logged out"}, nil)
+}
+
+// whoami shows the login and checks that its session is still valid
+func whoami(c *cli, cmd *command, args []string) error {
+	fs := c.flagSet(cmd)
+	if _, err := c.parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	if err := c.loadToken(); err != nil {
+		return err
+	}
+	if c.token == "" {
+		return usageErrorf("not logged in, run dimcli login or set IONOSDIM_TOKEN")
+	}
+	if c.endpoint == "" {
+		return usageErrorf("DIM endpoint must be specified. Set the endpoint value as command line argument or use the IONOSDIM_ENDPOINT environment variable.")
+	}
+
+	s := c.session
+	if s == nil {
+		s = &savedSession{Endpoint: c.endpoint, Username: c.username, TokenFile: c.tokenFile}
+	}
+	fields := s.fields()
+
+	// no username and password, so that an expired session is not renewed
+	noUser := ""
+	dimC, err := dim.NewClientWithContext(c.ctx, &c.endpoint, &c.token, &noUser, &noUser, nil, dim.WithTransport(c.transport))
+	if err != nil {
+		return err
+	}
+	_, err = dimC.Layer3domainList(c.ctx)
+	expired := errors.Is(err, dim.ErrSessionExpired)
+	if err != nil && !expired {
+		return err
+	}
+	fields["session"] = "valid"
+	if expired {
+		fields["session"] = "expired"
+	}
+	if err := c.print(fields, nil); err != nil {
+		return err
+	}
+	if expired {
+		return errors.New("DIM session expired, run dimcli login")
+	}
+	return nil
+}
+
+// fields returns the fields of the session shown by the commands
+func (s *savedSession) fields() map[string]string {
+	fields := map[string]string{
+		"endpoint":   s.Endpoint,
+		"username":   s.Username,
+		"token_file": s.TokenFile,
+	}
+	if !s.LoggedIn.IsZero() {
+		fields["logged_in"] = s.LoggedIn.Format(time.RFC3339)
+	}
+	return fields
+}
+
+// readPassword reads the password without echo from the terminal,
+// or a line from stdin if it is not a terminal, e.g. a pipe
+func readPassword(stdin *bufio.Reader) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return readLine(stdin)
+	}
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	return string(password), err
+}
+
+func readLine(stdin *bufio.Reader) (string, error) {
+	line, err := stdin.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("could not read stdin: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
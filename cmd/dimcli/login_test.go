@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"terraform-provider-ionosdim/pkg/dim/dimtest"
+
+	"github.com/go-kit/log"
+)
+
+func wantMode(t *testing.T, name string, wants os.FileMode) {
+	t.Helper()
+	fi, err := os.Stat(name)
+	if err != nil {
+		t.Errorf("stat %s: %s", name, err)
+		return
+	}
+	if got := fi.Mode().Perm(); got != wants {
+		t.Errorf("mode of %s = %o ; wants = %o", name, got, wants)
+	}
+}
+
+func wantNotExist(t *testing.T, name string) {
+	t.Helper()
+	if _, err := os.Stat(name); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("stat %s error = %v ; wants not exist", name, err)
+	}
+}
+
+// newLoggedOutCLI returns the cli with the settings from the environment only,
+// i.e. without endpoint and credentials
+func newLoggedOutCLI() (*cli, *bytes.Buffer) {
+	var out bytes.Buffer
+	return &cli{ctx: context.Background(), logger: log.NewNopLogger(), output: "table", stdout: &out}, &out
+}
+
+func TestWritePrivateFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "ionosdim")
+	name := filepath.Join(dir, "token")
+	if err := writePrivateFile(name, []byte("first\n")); err != nil {
+		t.Fatalf("writePrivateFile() error: %s", err)
+	}
+	wantMode(t, dir, 0o700)
+	wantMode(t, name, 0o600)
+
+	// an existing file readable by others is made private
+	if err := os.Chmod(name, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writePrivateFile(name, []byte("second\n")); err != nil {
+		t.Fatalf("writePrivateFile() error: %s", err)
+	}
+	wantMode(t, name, 0o600)
+	if token, err := readToken(name); err != nil || token != "second" {
+		t.Errorf("readToken() = %q, %v ; wants = %q", token, err, "second")
+	}
+}
+
+func TestLoginLogout(t *testing.T) {
+	s, c, out := newTestCLI(t)
+	if err := run(c, "login"); err != nil {
+		t.Fatalf("login error: %s", err)
+	}
+	dir, err := configDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenFile, sessionFile := filepath.Join(dir, "token"), filepath.Join(dir, "session.yaml")
+	wantMode(t, dir, 0o700)
+	wantMode(t, tokenFile, 0o600)
+	wantMode(t, sessionFile, 0o600)
+	if got := fieldsOf(t, out); got["endpoint"] != s.URL || got["username"] != dimtest.Username || got["token_file"] != tokenFile {
+		t.Errorf("login = %q", got)
+	}
+
+	// the saved session is used without endpoint and credentials
+	c, out = newLoggedOutCLI()
+	if err := run(c, "whoami"); err != nil {
+		t.Fatalf("whoami error: %s", err)
+	}
+	if got := fieldsOf(t, out); got["endpoint"] != s.URL || got["username"] != dimtest.Username || got["session"] != "valid" {
+		t.Errorf("whoami = %q", got)
+	}
+	token := c.token
+
+	c, out = newLoggedOutCLI()
+	if err := run(c, "logout"); err != nil {
+		t.Fatalf("logout error: %s", err)
+	}
+	if got := fieldsOf(t, out); got["result"] != "logged out" || got["token_file"] != tokenFile {
+		t.Errorf("logout = %q", got)
+	}
+	wantNotExist(t, tokenFile)
+	wantNotExist(t, sessionFile)
+
+	// the token is not valid after logout
+	c, out = newLoggedOutCLI()
+	c.endpoint, c.token = s.URL, token
+	err = run(c, "whoami")
+	if code := c.exitCode(err); code != exitFailure {
+		t.Errorf("whoami after logout exit code = %d (%v) ; wants = %d", code, err, exitFailure)
+	}
+	if got := fieldsOf(t, out); got["session"] != "expired" {
+		t.Errorf("whoami after logout = %q", got)
+	}
+
+	c, _ = newLoggedOutCLI()
+	err = run(c, "logout")
+	if code := c.exitCode(err); code != exitUsage {
+		t.Errorf("logout without session exit code = %d (%v) ; wants = %d", code, err, exitUsage)
+	}
+}
+
+func TestLoginTokenFile(t *testing.T) {
+	s, c, _ := newTestCLI(t)
+	c.tokenFile = filepath.Join(t.TempDir(), "dim.token")
+	if err := os.WriteFile(c.tokenFile, []byte("old\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(c, "login", "-username", dimtest.Username); err != nil {
+		t.Fatalf("login error: %s", err)
+	}
+	wantMode(t, c.tokenFile, 0o600)
+
+	// logout removes the -token file, and the session saved with it
+	c2, _ := newLoggedOutCLI()
+	c2.tokenFile = c.tokenFile
+	if err := run(c2, "logout"); err != nil {
+		t.Fatalf("logout error: %s", err)
+	}
+	wantNotExist(t, c.tokenFile)
+	name, err := sessionFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantNotExist(t, name)
+	if c2.endpoint != s.URL {
+		t.Errorf("logout endpoint = %q ; wants = %q", c2.endpoint, s.URL)
+	}
+}
+
+func TestLoadToken(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir, err := configDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenFile := filepath.Join(dir, "token")
+	otherFile := filepath.Join(t.TempDir(), "other.token")
+	for name, token := range map[string]string{tokenFile: "saved\n", otherFile: " other \n"} {
+		if err := writePrivateFile(name, []byte(token)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	name, err := sessionFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	session := "endpoint: https://dim.example.com\nusername: admin\ntoken_file: " + tokenFile + "\n"
+	if err := writePrivateFile(name, []byte(session)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		c cli
+
+		wantsEndpoint string
+		wantsToken    string
+		wantsSession  bool
+		wantsErr      bool
+	}{
+		// the saved session, with its endpoint
+		{c: cli{}, wantsEndpoint: "https://dim.example.com", wantsToken: "saved", wantsSession: true},
+		{c: cli{endpoint: "https://dim.example.com"}, wantsEndpoint: "https://dim.example.com", wantsToken: "saved", wantsSession: true},
+		// not the session of another endpoint
+		{c: cli{endpoint: "https://other.example.com"}, wantsEndpoint: "https://other.example.com"},
+		// the -token file and IONOSDIM_TOKEN take precedence
+		{c: cli{tokenFile: otherFile}, wantsToken: "other"},
+		{c: cli{token: "env"}, wantsToken: "env"},
+		{c: cli{tokenFile: filepath.Join(dir, "missing")}, wantsErr: true},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		c := tests[i].c
+		err := c.loadToken()
+		if tests[i].wantsErr {
+			if err == nil {
+				t.Errorf("loadToken(%+v) ; wants error", tests[i].c)
+			}
+			continue
+		}
+		if err != nil || c.endpoint != tests[i].wantsEndpoint || c.token != tests[i].wantsToken || (c.session != nil) != tests[i].wantsSession {
+			t.Errorf("loadToken(%+v) = %q, %q, session %v, %v ; wants = %q, %q, session %v", tests[i].c,
+				c.endpoint, c.token, c.session != nil, err, tests[i].wantsEndpoint, tests[i].wantsToken, tests[i].wantsSession)
+		}
+	}
+
+	// the saved token file is gone, e.g. removed by hand
+	if err := os.Remove(tokenFile); err != nil {
+		t.Fatal(err)
+	}
+	c := cli{}
+	if err := c.loadToken(); err == nil {
+		t.Errorf("loadToken() without the saved token file ; wants error")
+	}
+}
@@ -1,109 +1,274 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 
 	"terraform-provider-ionosdim/pkg/dim"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
+)
 
-	"gopkg.in/yaml.v3"
+// exit codes
+const (
+	exitOK       = 0
+	exitDimError = 1 // DIM returned an error
+	exitUsage    = 2 // invalid command line
+	exitFailure  = 3 // any other failure, e.g. DIM could not be reached
 )
 
-func toYaml(v interface{}) (string, error) {
-	bytes, err := yaml.Marshal(v)
-	return string(bytes), err
+// usageError is returned by the commands for the invalid command line
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+func usageErrorf(format string, a ...any) error {
+	return usageError{msg: fmt.Sprintf(format, a...)}
+}
+
+// command is a dimcli subcommand, e.g. "ip get"
+type command struct {
+	name     string
+	synopsis string
+	run      func(c *cli, cmd *command, args []string) error
+}
+
+var commands = []command{
+	{"ip get", "<ip> [-layer3domain name]", ipGet},
+	{"ip allocate", "-pool name [-ip ip] [-comment text] [-attr key=value]...", ipAllocate},
+	{"ip free", "<ip> [-pool name] [-layer3domain name]", ipFree},
+	{"rr list", "[-type type] [-pattern pattern] [-zone zone] [-view view] [-layer3domain name] [-created-by user] [-comment text]", rrList},
+	{"rr create", "-type type -name name [-zone zone] [-view view] [-ttl seconds] [-comment text] <value flags>", rrCreate},
+	{"rr delete", "-type type -name name [-zone zone] [-view view] <value flags>", rrDelete},
+	{"pool show", "<pool>", poolShow},
+	{"zone show", "<zone> [-view view] [-profile]", zoneShow},
+	{"login", "[-username name]", login},
+	{"logout", "", logout},
+	{"whoami", "", whoami},
+	{"batch", "[-continue-on-error] [-dry-run] <file>", batch},
+}
+
+// findCommand returns the command named by the first one or two args
+// and the remaining args
+func findCommand(args []string) (*command, []string) {
+	for n := 2; n >= 1; n-- {
+		if len(args) < n {
+			continue
+		}
+		name := strings.Join(args[:n], " ")
+		for i := range commands {
+			if commands[i].name == name {
+				return &commands[i], args[n:]
+			}
+		}
+	}
+	return nil, args
+}
+
+// cli holds the global settings shared by the commands
+type cli struct {
+	ctx    context.Context
+	logger log.Logger
+	output string
+	stdout io.Writer // the output of the commands
+
+	endpoint  string
+	token     string
+	tokenFile string
+	username  string
+	password  string
+	transport dim.TransportOptions
+
+	// session is the login saved by dimcli login, if its token is used
+	session *savedSession
+	client  *dim.Client
+}
+
+// dimClient returns the DIM client, logging in on the first call
+func (c *cli) dimClient() (*dim.Client, error) {
+	if c.client != nil {
+		return c.client, nil
+	}
+	if err := c.loadToken(); err != nil {
+		return nil, err
+	}
+	if c.endpoint == "" {
+		return nil, usageErrorf("DIM endpoint must be specified. Set the endpoint value as command line argument or use the IONOSDIM_ENDPOINT environment variable.")
+	}
+	if c.token == "" {
+		if c.username == "" {
+			return nil, usageErrorf("DIM username must be specified. Use the IONOSDIM_USERNAME environment variable. Alternatively you may specify the token value in IONOSDIM_TOKEN environment variable or in the file spicified with token command line argument.")
+		}
+		if c.password == "" {
+			return nil, usageErrorf("DIM password must be specified. Use the IONOSDIM_PASSWORD environment variable. Alternatively you may specify the token value in IONOSDIM_TOKEN environment variable or in the file spicified with token command line argument.")
+		}
+	}
+	client, err := dim.NewClientWithContext(c.ctx, &c.endpoint, &c.token, &c.username, &c.password, nil, dim.WithTransport(c.transport))
+	if err != nil {
+		return nil, err
+	}
+	c.client = client
+	return client, nil
+}
+
+// flagSet returns the flag set of the command with the output flag
+func (c *cli) flagSet(cmd *command) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.StringVar(&c.output, "o", c.output, "output format: table, yaml or json")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: dimcli %s %s\n", cmd.name, cmd.synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses the command flags and returns
+// exactly nargs positional arguments
+func (c *cli) parseFlags(fs *flag.FlagSet, args []string, nargs int) ([]string, error) {
+	// allow the flags after the positional arguments, e.g. "ip get 10.0.0.1 -o json"
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, usageError{msg: err.Error()}
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != nargs {
+		fs.Usage()
+		return nil, usageErrorf("%s expects %d argument(s), got %d", fs.Name(), nargs, len(positional))
+	}
+	switch c.output {
+	case "table", "yaml", "json":
+	default:
+		return nil, usageErrorf("unknown output format %q", c.output)
+	}
+	return positional, nil
 }
 
-func toJson(v interface{}) (string, error) {
-	bytes, err := json.Marshal(v)
-	return string(bytes), err
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: dimcli [flags] <command> [command flags]\n\nCommands:\n")
+	names := make([]string, len(commands))
+	for i, cmd := range commands {
+		names[i] = cmd.name
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %s\n", name)
+	}
+	fmt.Fprintf(out, "\nWithout a command, the DIM function given by -func is called with -args.\n\nFlags:\n")
+	flag.PrintDefaults()
 }
 
 func main() {
 
-	dimEndpoint := os.Getenv("IONOSDIM_ENDPOINT")
-	dimUsername := os.Getenv("IONOSDIM_USERNAME")
-	dimPassword := os.Getenv("IONOSDIM_PASSWORD")
-	dimToken := os.Getenv("IONOSDIM_TOKEN")
+	c := &cli{
+		ctx:      context.Background(),
+		stdout:   os.Stdout,
+		endpoint: os.Getenv("IONOSDIM_ENDPOINT"),
+		username: os.Getenv("IONOSDIM_USERNAME"),
+		password: os.Getenv("IONOSDIM_PASSWORD"),
+		token:    os.Getenv("IONOSDIM_TOKEN"),
+	}
 
-	dimTokenFile := flag.String("token", "", "name of the file with session token (cookie) for a DIM account")
+	flag.StringVar(&c.tokenFile, "token", "", "name of the file with session token (cookie) for a DIM account, the one saved by login if not set")
 	dimEndpointCla := flag.String("endpoint", "", "DIM endpoint URL")
 	dimFunc := flag.String("func", "server_info", "dim function")
 	dimFuncArgs := flag.String("args", "[]", "dim function args (as json array)")
 	outJson := flag.Bool("j", false, "output as json instead of yaml")
+	flag.StringVar(&c.output, "o", "", "output format: table, yaml or json (default table for the commands, yaml for -func)")
+	flag.DurationVar(&c.transport.Timeout, "timeout", dim.DefaultTimeout, "timeout of a DIM request")
+	flag.StringVar(&c.transport.CACertFile, "cacert", "", "name of the PEM file with CA certificates trusted in addition to the system ones")
+	flag.StringVar(&c.transport.ClientCertFile, "cert", "", "name of the PEM file with client certificate for mutual TLS")
+	flag.StringVar(&c.transport.ClientKeyFile, "key", "", "name of the PEM file with key of the client certificate")
+	flag.BoolVar(&c.transport.InsecureSkipVerify, "insecure", false, "do not verify the DIM certificate")
+	flag.StringVar(&c.transport.ProxyURL, "proxy", "", "http proxy URL (the proxy environment variables are used if empty)")
+	flag.Usage = usage
 	flag.Parse()
 
 	logger := log.NewLogfmtLogger(log.NewSyncWriter(os.Stderr))
 	logger = level.NewFilter(logger, level.AllowError())
 	logger = log.With(logger, "ts", log.DefaultTimestampUTC, "caller", log.DefaultCaller)
 	level.Info(logger).Log("msg", "starting dim cli")
-
-	var dimTokenCla string
-	if *dimTokenFile != "" {
-		_token, err := os.ReadFile(*dimTokenFile)
-		if err != nil {
-			level.Error(logger).Log("msg", "could not read dim token file", "file", *dimTokenFile, "err", err)
-			os.Exit(1)
-		}
-		dimTokenCla = strings.TrimSpace(string(_token))
-	}
-
-	var _dimFuncArgs interface{}
-
-	err := json.Unmarshal([]byte(*dimFuncArgs), &_dimFuncArgs)
-	if err != nil {
-		level.Error(logger).Log("msg", "could not unmarshal args", "err", err)
-		os.Exit(1)
-	}
+	c.logger = logger
 
 	if *dimEndpointCla != "" {
-		dimEndpoint = *dimEndpointCla
+		c.endpoint = *dimEndpointCla
 	}
-
-	if dimTokenCla != "" {
-		dimToken = dimTokenCla
+	if *outJson {
+		c.output = "json"
 	}
 
-	if dimEndpoint == "" {
-		level.Error(logger).Log("msg", "DIM endpoint must be specified. Set the endpoint value as command line argument or use the IONOSDIM_ENDPOINT environment variable.", "err", err)
-		os.Exit(1)
+	var err error
+	if flag.NArg() == 0 {
+		err = c.rawCall(*dimFunc, *dimFuncArgs)
+	} else if cmd, args := findCommand(flag.Args()); cmd != nil {
+		if c.output == "" {
+			c.output = "table"
+		}
+		err = cmd.run(c, cmd, args)
+	} else {
+		flag.Usage()
+		err = usageErrorf("unknown command %q", strings.Join(flag.Args(), " "))
 	}
 
-	if dimToken == "" {
-		if dimUsername == "" {
-			level.Error(logger).Log("msg", "DIM username must be specified. Use the IONOSDIM_USERNAME environment variable. Alternatively you may specify the token value in IONOSDIM_TOKEN environment variable or in the file spicified with token command line argument.", "err", err)
-			os.Exit(1)
-		}
+	os.Exit(c.exitCode(err))
+}
 
-		if dimPassword == "" {
-			level.Error(logger).Log("msg", "DIM password must be specified. Use the IONOSDIM_PASSWORD environment variable. Alternatively you may specify the token value in IONOSDIM_TOKEN environment variable or in the file spicified with token command line argument.", "err", err)
-			os.Exit(1)
-		}
+// exitCode logs the error and returns the exit code for it
+func (c *cli) exitCode(err error) int {
+	if err == nil {
+		return exitOK
 	}
+	var usageErr usageError
+	var dimErr dim.Error
+	switch {
+	case errors.As(err, &usageErr):
+		level.Error(c.logger).Log("msg", "invalid command line", "err", err)
+		return exitUsage
+	case errors.As(err, &dimErr):
+		level.Error(c.logger).Log("msg", "dim request failed", "err", err)
+		return exitDimError
+	}
+	level.Error(c.logger).Log("msg", "dim request failed", "err", err)
+	return exitFailure
+}
 
-	dimC, err := dim.NewClient(&dimEndpoint, &dimToken, &dimUsername, &dimPassword, nil)
-	resp, err := dimC.RawCall(*dimFunc, _dimFuncArgs)
-	if err != nil {
-		level.Error(logger).Log("msg", "dim request failed", "err", err)
-		os.Exit(1)
+// rawCall calls the DIM function with the json array args
+// and prints the result as yaml or json
+func (c *cli) rawCall(function, args string) error {
+	var _dimFuncArgs interface{}
+	if err := json.Unmarshal([]byte(args), &_dimFuncArgs); err != nil {
+		return usageErrorf("could not unmarshal args: %s", err)
 	}
 
-	var out string
-	if *outJson {
-		out, err = toJson(resp)
-	} else {
-		out, err = toYaml(resp)
+	dimC, err := c.dimClient()
+	if err != nil {
+		return err
 	}
+	resp, err := dimC.RawCallWithContext(c.ctx, function, _dimFuncArgs)
 	if err != nil {
-		level.Error(logger).Log("msg", "could not marshal response output", "err", err)
-		os.Exit(1)
+		return err
 	}
 
-	fmt.Println(out)
+	if c.output == "" || c.output == "table" {
+		c.output = "yaml"
+	}
+	return c.print(resp, nil)
 }
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"terraform-provider-ionosdim/pkg/dim"
+	"terraform-provider-ionosdim/pkg/dim/dimtest"
+
+	"github.com/go-kit/log"
+)
+
+// newTestCLI returns the cli logging in to a new dimtest.Server
+// and the buffer of its output.
+// The config dir is a temporary one, so that no saved session is used.
+func newTestCLI(t *testing.T) (*dimtest.Server, *cli, *bytes.Buffer) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	s := dimtest.NewServer()
+	t.Cleanup(s.Close)
+	var out bytes.Buffer
+	c := &cli{
+		ctx:      context.Background(),
+		logger:   log.NewNopLogger(),
+		output:   "table",
+		stdout:   &out,
+		endpoint: s.URL,
+		username: dimtest.Username,
+		password: dimtest.Password,
+	}
+	return s, c, &out
+}
+
+// run runs the command line of the dimcli command, e.g. "ip get"
+func run(c *cli, args ...string) error {
+	cmd, args := findCommand(args)
+	if cmd == nil {
+		return fmt.Errorf("unknown command %q", args)
+	}
+	return cmd.run(c, cmd, args)
+}
+
+func TestFindCommand(t *testing.T) {
+	tests := []struct {
+		args      []string
+		wantsName string
+		wantsArgs []string
+	}{
+		{args: []string{"ip", "get", "10.0.0.1"}, wantsName: "ip get", wantsArgs: []string{"10.0.0.1"}},
+		{args: []string{"rr", "create", "-type", "A"}, wantsName: "rr create", wantsArgs: []string{"-type", "A"}},
+		{args: []string{"login", "-username", "admin"}, wantsName: "login", wantsArgs: []string{"-username", "admin"}},
+		{args: []string{"whoami"}, wantsName: "whoami", wantsArgs: []string{}},
+		{args: []string{"batch", "-"}, wantsName: "batch", wantsArgs: []string{"-"}},
+		{args: []string{"ip"}, wantsArgs: []string{"ip"}},
+		{args: []string{"ip", "list"}, wantsArgs: []string{"ip", "list"}},
+		{args: []string{"get", "ip"}, wantsArgs: []string{"get", "ip"}},
+		{args: []string{}, wantsArgs: []string{}},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		cmd, args := findCommand(tests[i].args)
+		name := ""
+		if cmd != nil {
+			name = cmd.name
+		}
+		if name != tests[i].wantsName || !reflect.DeepEqual(args, tests[i].wantsArgs) {
+			t.Errorf("findCommand(%q) = %q, %q ; wants = %q, %q", tests[i].args, name, args, tests[i].wantsName, tests[i].wantsArgs)
+		}
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		args  []string
+		nargs int
+
+		wantsArgs         []string
+		wantsOutput       string
+		wantsLayer3domain string
+		wantsErr          bool
+	}{
+		{args: []string{"10.0.0.1"}, nargs: 1, wantsArgs: []string{"10.0.0.1"}, wantsOutput: "table"},
+		{args: []string{"10.0.0.1", "-o", "json"}, nargs: 1, wantsArgs: []string{"10.0.0.1"}, wantsOutput: "json"},
+		{args: []string{"-o", "yaml", "10.0.0.1", "-layer3domain", "l3"}, nargs: 1, wantsArgs: []string{"10.0.0.1"}, wantsOutput: "yaml", wantsLayer3domain: "l3"},
+		{args: []string{"a", "-layer3domain=l3", "b"}, nargs: 2, wantsArgs: []string{"a", "b"}, wantsOutput: "table", wantsLayer3domain: "l3"},
+		{args: []string{"--", "-a"}, nargs: 1, wantsArgs: []string{"-a"}, wantsOutput: "table"},
+		{args: []string{}, nargs: 0, wantsArgs: nil, wantsOutput: "table"},
+		{args: []string{}, nargs: 1, wantsErr: true},
+		{args: []string{"a", "b"}, nargs: 1, wantsErr: true},
+		{args: []string{"a", "-o", "xml"}, nargs: 1, wantsErr: true},
+		{args: []string{"a", "-unknown"}, nargs: 1, wantsErr: true},
+		{args: []string{"a", "-layer3domain"}, nargs: 1, wantsErr: true},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		c := &cli{output: "table"}
+		fs := c.flagSet(&command{name: "ip get"})
+		fs.SetOutput(io.Discard)
+		layer3domain := fs.String("layer3domain", "", "")
+		args, err := c.parseFlags(fs, tests[i].args, tests[i].nargs)
+		if tests[i].wantsErr {
+			var usageErr usageError
+			if !errors.As(err, &usageErr) {
+				t.Errorf("parseFlags(%q, %d) error = %v ; wants usage error", tests[i].args, tests[i].nargs, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseFlags(%q, %d) error: %s", tests[i].args, tests[i].nargs, err)
+			continue
+		}
+		if !reflect.DeepEqual(args, tests[i].wantsArgs) || c.output != tests[i].wantsOutput || *layer3domain != tests[i].wantsLayer3domain {
+			t.Errorf("parseFlags(%q, %d) = %q, -o %q, -layer3domain %q ; wants = %q, -o %q, -layer3domain %q", tests[i].args, tests[i].nargs,
+				args, c.output, *layer3domain, tests[i].wantsArgs, tests[i].wantsOutput, tests[i].wantsLayer3domain)
+		}
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	dimErr := dim.Error{Func: "ip_mark", Code: dimtest.CodeInvalidIP, Message: "invalid ip"}
+	tests := []struct {
+		err   error
+		wants int
+	}{
+		{err: nil, wants: exitOK},
+		{err: usageErrorf("-pool must be specified"), wants: exitUsage},
+		{err: fmt.Errorf("could not read: %w", usageErrorf("bad")), wants: exitUsage},
+		{err: dimErr, wants: exitDimError},
+		{err: fmt.Errorf("1 of 2 calls failed, first: %w", dimErr), wants: exitDimError},
+		{err: fmt.Errorf("1 of 2 calls failed, first: %s", dimErr), wants: exitFailure},
+		{err: errors.New("connection refused"), wants: exitFailure},
+	}
+
+	c := &cli{logger: log.NewNopLogger()}
+	for i := 0; i < len(tests); i++ {
+		if got := c.exitCode(tests[i].err); got != tests[i].wants {
+			t.Errorf("exitCode(%v) = %d ; wants = %d", tests[i].err, got, tests[i].wants)
+		}
+	}
+}
+
+func TestIPCommands(t *testing.T) {
+	s, c, out := newTestCLI(t)
+	if err := s.AddPool("some-pool", "10.88.8.0/30"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run(c, "ip", "allocate", "-pool", "some-pool", "-comment", "first", "-attr", "owner=network"); err != nil {
+		t.Fatalf("ip allocate error: %s", err)
+	}
+	got := fieldsOf(t, out)
+	if got["ip"] != "10.88.8.2" || got["pool"] != "some-pool" || got["comment"] != "first" || got["owner"] != "network" {
+		t.Errorf("ip allocate = %q", got)
+	}
+
+	out.Reset()
+	if err := run(c, "ip", "get", "10.88.8.2", "-o", "json"); err != nil {
+		t.Fatalf("ip get error: %s", err)
+	}
+	if !bytes.Contains(out.Bytes(), []byte(`"ip":"10.88.8.2"`)) || !bytes.Contains(out.Bytes(), []byte(`"owner":"network"`)) {
+		t.Errorf("ip get -o json = %s", out)
+	}
+
+	c.output = "table"
+	out.Reset()
+	if err := run(c, "ip", "free", "10.88.8.2"); err != nil {
+		t.Fatalf("ip free error: %s", err)
+	}
+	if got := fieldsOf(t, out); got["result"] != "freed" {
+		t.Errorf("ip free = %q", got)
+	}
+	out.Reset()
+	if err := run(c, "ip", "free", "10.88.8.2"); err != nil {
+		t.Fatalf("ip free error: %s", err)
+	}
+	if got := fieldsOf(t, out); got["result"] != "already free" {
+		t.Errorf("ip free = %q", got)
+	}
+
+	// .3 is the broadcast address
+	err := run(c, "ip", "allocate", "-pool", "some-pool", "-ip", "10.88.8.3")
+	if code := c.exitCode(err); code != exitDimError {
+		t.Errorf("ip allocate -ip 10.88.8.3 exit code = %d (%v) ; wants = %d", code, err, exitDimError)
+	}
+	err = run(c, "ip", "allocate")
+	if code := c.exitCode(err); code != exitUsage {
+		t.Errorf("ip allocate exit code = %d (%v) ; wants = %d", code, err, exitUsage)
+	}
+}
+
+// fieldsOf returns the fields of the FIELD VALUE table output
+func fieldsOf(t *testing.T, out *bytes.Buffer) map[string]string {
+	t.Helper()
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	if len(lines) == 0 || !bytes.Equal(bytes.Fields(lines[0])[0], []byte("FIELD")) {
+		t.Fatalf("output is not a fields table: %s", out)
+	}
+	fields := map[string]string{}
+	for _, line := range lines[1:] {
+		name, value, _ := bytes.Cut(line, []byte(" "))
+		fields[string(name)] = string(bytes.TrimSpace(value))
+	}
+	return fields
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+func toYaml(v interface{}) (string, error) {
+	bytes, err := yaml.Marshal(v)
+	return string(bytes), err
+}
+
+func toJson(v interface{}) (string, error) {
+	bytes, err := json.Marshal(v)
+	return string(bytes), err
+}
+
+// table is the tabular output of a command
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// print prints the result in the output format.
+// The table output of a result without t is a list of the fields and values,
+// or yaml if the result is not an object.
+func (c *cli) print(v any, t *table) error {
+	// the json round trip makes the yaml keys match the DIM field names
+	v, err := generic(v)
+	if err != nil {
+		return fmt.Errorf("could not marshal response output: %w", err)
+	}
+
+	var out string
+	switch c.output {
+	case "json":
+		out, err = toJson(v)
+	case "table":
+		if t == nil {
+			if m, ok := v.(map[string]any); ok {
+				t = fieldsTable(m)
+			}
+		}
+		if t != nil {
+			return t.write(c.stdout)
+		}
+		fallthrough
+	default:
+		out, err = toYaml(v)
+	}
+	if err != nil {
+		return fmt.Errorf("could not marshal response output: %w", err)
+	}
+
+	fmt.Fprintln(c.stdout, strings.TrimSuffix(out, "\n"))
+	return nil
+}
+
+func (t *table) write(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(t.header, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// fieldsTable returns the table of the fields of the object sorted by name
+func fieldsTable(m map[string]any) *table {
+	t := &table{header: []string{"FIELD", "VALUE"}}
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t.rows = append(t.rows, []string{name, cell(m[name])})
+	}
+	return t
+}
+
+// cell formats the value as a table cell
+func cell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64, float64, bool:
+		return fmt.Sprint(v)
+	}
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(bytes)
+}
+
+// ptrCell formats the optional value as a table cell
+func ptrCell[T any](v *T) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(*v)
+}
+
+// generic returns the json representation of v as maps, slices and scalars
+func generic(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber() // keep the integers as they are
+	var res any
+	if err := d.Decode(&res); err != nil {
+		return nil, err
+	}
+	return numbers(res), nil
+}
+
+// numbers replaces the json numbers in v with int64 or float64,
+// yaml would quote them otherwise
+func numbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]any:
+		for k, e := range v {
+			v[k] = numbers(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = numbers(e)
+		}
+	}
+	return v
+}
+
+// object returns the json representation of the struct v as a map
+func object(v any) (map[string]any, error) {
+	g, err := generic(v)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := g.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%T is not an object", v)
+	}
+	return m, nil
+}
+
+// withAttributes returns the object v with the user defined attributes
+// merged in, the way DIM returns them
+func withAttributes(v any, attrs map[string]string) (map[string]any, error) {
+	m, err := object(v)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range attrs {
+		if _, ok := m[name]; !ok {
+			m[name] = value
+		}
+	}
+	return m, nil
+}
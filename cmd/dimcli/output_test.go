@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCell(t *testing.T) {
+	tests := []struct {
+		input any
+		wants string
+	}{
+		{input: nil, wants: ""},
+		{input: "text", wants: "text"},
+		{input: int64(9007199254740993), wants: "9007199254740993"},
+		{input: 1.5, wants: "1.5"},
+		{input: true, wants: "true"},
+		{input: []any{"a", int64(1)}, wants: `["a",1]`},
+		{input: map[string]any{"b": "2", "a": nil}, wants: `{"a":null,"b":"2"}`},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		if got := cell(tests[i].input); got != tests[i].wants {
+			t.Errorf("cell(%#v) = %q ; wants = %q", tests[i].input, got, tests[i].wants)
+		}
+	}
+}
+
+func TestGeneric(t *testing.T) {
+	type item struct {
+		Name  string   `json:"name"`
+		Total *int64   `json:"total"`
+		Ratio float64  `json:"ratio"`
+		Tags  []string `json:"tags"`
+	}
+	total := int64(9007199254740993) // 2^53 + 1, not exact as float64
+	tests := []struct {
+		input any
+		wants any
+	}{
+		{input: "text", wants: "text"},
+		{input: 3600, wants: int64(3600)},
+		{input: json.RawMessage(`18446744073709551616`), wants: 1.8446744073709552e+19},
+		{input: item{Name: "a", Total: &total, Ratio: 0.5, Tags: []string{"x"}},
+			wants: map[string]any{"name": "a", "total": total, "ratio": 0.5, "tags": []any{"x"}}},
+		{input: []item{{Name: "b"}},
+			wants: []any{map[string]any{"name": "b", "total": nil, "ratio": int64(0), "tags": nil}}},
+		{input: map[string]string{"result": "freed"}, wants: map[string]any{"result": "freed"}},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		got, err := generic(tests[i].input)
+		if err != nil || !reflect.DeepEqual(got, tests[i].wants) {
+			t.Errorf("generic(%#v) = %#v, %v ; wants = %#v", tests[i].input, got, err, tests[i].wants)
+		}
+	}
+}
+
+func TestWithAttributes(t *testing.T) {
+	type attrs struct {
+		IP      string  `json:"ip"`
+		Comment *string `json:"comment"`
+	}
+	got, err := withAttributes(attrs{IP: "10.0.0.1"}, map[string]string{"owner": "network", "ip": "ignored"})
+	wants := map[string]any{"ip": "10.0.0.1", "comment": nil, "owner": "network"}
+	if err != nil || !reflect.DeepEqual(got, wants) {
+		t.Errorf("withAttributes() = %#v, %v ; wants = %#v", got, err, wants)
+	}
+
+	if _, err := withAttributes([]string{"a"}, nil); err == nil {
+		t.Errorf("withAttributes() of a list ; wants error")
+	}
+}
+
+func TestPrint(t *testing.T) {
+	type result struct {
+		IP    string `json:"ip"`
+		TTL   int64  `json:"ttl"`
+		Empty *int64 `json:"empty"`
+	}
+	list := &table{header: []string{"IP", "TTL"}, rows: [][]string{{"10.0.0.1", "3600"}, {"10.0.0.10", ""}}}
+	tests := []struct {
+		output string
+		input  any
+		table  *table
+		wants  string
+	}{
+		{output: "table", input: result{IP: "10.0.0.1", TTL: 3600},
+			wants: "FIELD  VALUE\nempty  \nip     10.0.0.1\nttl    3600\n"},
+		{output: "table", input: []result{}, table: list,
+			wants: "IP         TTL\n10.0.0.1   3600\n10.0.0.10  \n"},
+		// not an object and no table
+		{output: "table", input: []string{"a", "b"}, wants: "- a\n- b\n"},
+		{output: "yaml", input: result{IP: "10.0.0.1", TTL: 3600}, table: list,
+			wants: "empty: null\nip: 10.0.0.1\nttl: 3600\n"},
+		{output: "json", input: result{IP: "10.0.0.1", TTL: 9007199254740993},
+			wants: `{"empty":null,"ip":"10.0.0.1","ttl":9007199254740993}` + "\n"},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		var out bytes.Buffer
+		c := &cli{output: tests[i].output, stdout: &out}
+		if err := c.print(tests[i].input, tests[i].table); err != nil || out.String() != tests[i].wants {
+			t.Errorf("print(%#v) -o %s = %q, %v ; wants = %q", tests[i].input, tests[i].output, out.String(), err, tests[i].wants)
+		}
+	}
+}
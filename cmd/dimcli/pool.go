@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+
+	"terraform-provider-ionosdim/pkg/dim"
+)
+
+// poolShow prints the attributes and the subnets of the pool
+func poolShow(c *cli, cmd *command, args []string) error {
+	fs := c.flagSet(cmd)
+	positional, err := c.parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	dimC, err := c.dimClient()
+	if err != nil {
+		return err
+	}
+	pool := positional[0]
+	attrs, err := dimC.IPPoolGetAttrs(c.ctx, pool)
+	if err != nil {
+		return err
+	}
+	subnets, err := dimC.IPList(c.ctx, dim.IPListOptions{
+		Pool: pool,
+		Type: "subnets",
+		Full: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.output == "table" {
+		if err := c.print(attrs, nil); err != nil {
+			return err
+		}
+		t := &table{header: []string{"SUBNET", "GATEWAY", "TOTAL", "FREE", "COMMENT"}}
+		for _, s := range subnets {
+			t.rows = append(t.rows, []string{s.IP, s.Gateway, ptrCell(s.Total), ptrCell(s.Free), ptrCell(s.Comment)})
+		}
+		fmt.Fprintln(c.stdout)
+		return t.write(c.stdout)
+	}
+
+	v, err := withAttributes(attrs, attrs.Attributes)
+	if err != nil {
+		return err
+	}
+	v["subnets"] = subnets
+	return c.print(v, nil)
+}
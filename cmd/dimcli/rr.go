@@ -0,0 +1,171 @@
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"terraform-provider-ionosdim/pkg/dim"
+)
+
+// rrList prints the resource records matching the filters
+func rrList(c *cli, cmd *command, args []string) error {
+	fs := c.flagSet(cmd)
+	var opts dim.RRListOptions
+	fs.StringVar(&opts.Type, "type", "", "type of the records, e.g. A")
+	fs.StringVar(&opts.Pattern, "pattern", "", "pattern of the record names, e.g. www*")
+	fs.StringVar(&opts.Zone, "zone", "", "zone of the records")
+	fs.StringVar(&opts.View, "view", "", "view of the zone")
+	fs.StringVar(&opts.Layer3domain, "layer3domain", "", "layer3domain of the records")
+	fs.StringVar(&opts.CreatedBy, "created-by", "", "user who created the records")
+	fs.StringVar(&opts.Comment, "comment", "", "comment of the records")
+	if _, err := c.parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	opts.Type = strings.ToUpper(opts.Type)
+	// the comment and the audit fields are shown in yaml and json output only
+	opts.Fields = c.output != "table"
+
+	dimC, err := c.dimClient()
+	if err != nil {
+		return err
+	}
+	rrs, err := dimC.RRList(c.ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"RECORD", "ZONE", "VIEW", "TTL", "TYPE", "VALUE"}}
+	for _, rr := range rrs {
+		t.rows = append(t.rows, []string{rr.Record, rr.Zone, rr.View, ptrCell(rr.TTL), rr.Type, rr.Value})
+	}
+	return c.print(rrs, t)
+}
+
+// rrCreate creates the resource record
+func rrCreate(c *cli, cmd *command, args []string) error {
+	fs := c.flagSet(cmd)
+	rf := newRRFlags(fs)
+	var opts dim.RRCreateOptions
+	var ttl int64Flag
+	fs.Var(&ttl, "ttl", "TTL of the record in seconds, the zone default if not set")
+	var comment stringFlag
+	fs.Var(&comment, "comment", "comment of the record")
+	fs.BoolVar(&opts.Overwrite, "overwrite", false, "overwrite the existing records of the name")
+	if _, err := c.parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	rr, err := rf.record(fs)
+	if err != nil {
+		return err
+	}
+	opts.TTL, opts.Comment = ttl.v, comment.v
+
+	dimC, err := c.dimClient()
+	if err != nil {
+		return err
+	}
+	if err := dimC.RRCreate(c.ctx, rr, opts); err != nil {
+		return err
+	}
+	return c.printRR(rr)
+}
+
+// rrDelete deletes the resource record
+func rrDelete(c *cli, cmd *command, args []string) error {
+	fs := c.flagSet(cmd)
+	rf := newRRFlags(fs)
+	var opts dim.RRDeleteOptions
+	fs.StringVar(&opts.References, "references", "", "what to do with the records referencing the deleted one: delete, ignore or warn")
+	if _, err := c.parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	rr, err := rf.record(fs)
+	if err != nil {
+		return err
+	}
+
+	dimC, err := c.dimClient()
+	if err != nil {
+		return err
+	}
+	if err := dimC.RRDelete(c.ctx, rr, opts); err != nil {
+		return err
+	}
+	return c.print(map[string]string{"type": rr.Type, "name": rr.Name, "zone": rr.Zone, "result": "deleted"}, nil)
+}
+
+// printRR prints the attributes of the created record
+func (c *cli) printRR(rr dim.RR) error {
+	fqdn := rr.Name
+	if rr.Zone != "" && !strings.HasSuffix(fqdn, ".") {
+		fqdn = strings.TrimSuffix(fqdn, "@")
+		if fqdn != "" {
+			fqdn += "."
+		}
+		fqdn += rr.Zone + "."
+	}
+	dimC, err := c.dimClient()
+	if err != nil {
+		return err
+	}
+	// rr_get_attrs accepts the fqdn only
+	id := rr
+	id.Name, id.Zone = fqdn, ""
+	attrs, err := dimC.RRGetAttrs(c.ctx, id)
+	if err != nil {
+		return err
+	}
+	return c.print(attrs, nil)
+}
+
+// rrFlags are the flags identifying a resource record
+type rrFlags struct {
+	rr      dim.RR
+	strings listFlag
+	fields  mapFlag
+
+	preference, priority, weight, port int64Flag
+}
+
+func newRRFlags(fs *flag.FlagSet) *rrFlags {
+	f := &rrFlags{fields: mapFlag{}}
+	fs.StringVar(&f.rr.Type, "type", "", "type of the record, e.g. A (required)")
+	fs.StringVar(&f.rr.Name, "name", "", "name of the record, relative to -zone or a fqdn with trailing dot (required)")
+	fs.StringVar(&f.rr.Zone, "zone", "", "zone of the record")
+	fs.StringVar(&f.rr.View, "view", "", "view of the zone")
+	fs.StringVar(&f.rr.Layer3domain, "layer3domain", "", "layer3domain of the ip address")
+	fs.StringVar(&f.rr.IP, "ip", "", "ip address of an A, AAAA or PTR record")
+	fs.StringVar(&f.rr.CNAME, "cname", "", "canonical name of a CNAME record")
+	fs.Var(&f.strings, "txt", "string of a TXT record, may be repeated")
+	fs.StringVar(&f.rr.PTRDName, "ptrdname", "", "domain name of a PTR record")
+	fs.Var(&f.preference, "preference", "preference of a MX record")
+	fs.StringVar(&f.rr.Exchange, "exchange", "", "exchange of a MX record")
+	fs.Var(&f.priority, "priority", "priority of a SRV record")
+	fs.Var(&f.weight, "weight", "weight of a SRV record")
+	fs.Var(&f.port, "port", "port of a SRV record")
+	fs.StringVar(&f.rr.Target, "target", "", "target of a SRV record")
+	fs.Var(f.fields, "field", "field of the other record types as key=value, may be repeated")
+	return f
+}
+
+// record returns the record given by the flags
+func (f *rrFlags) record(fs *flag.FlagSet) (dim.RR, error) {
+	rr := f.rr
+	if rr.Type == "" || rr.Name == "" {
+		fs.Usage()
+		return rr, usageErrorf("-type and -name must be specified")
+	}
+	rr.Type = strings.ToUpper(rr.Type)
+	rr.Strings = f.strings
+	rr.Preference = f.preference.v
+	rr.Priority = f.priority.v
+	rr.Weight = f.weight.v
+	rr.Port = f.port.v
+	if len(f.fields) > 0 {
+		rr.Fields = make(map[string]any, len(f.fields))
+		for k, v := range f.fields {
+			rr.Fields[k] = v
+		}
+	}
+	return rr, nil
+}
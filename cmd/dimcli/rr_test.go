@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+
+	"terraform-provider-ionosdim/pkg/dim"
+	"terraform-provider-ionosdim/pkg/dim/dimtest"
+)
+
+func TestRRFlagsRecord(t *testing.T) {
+	int64Ptr := func(v int64) *int64 { return &v }
+	tests := []struct {
+		args     []string
+		wants    dim.RR
+		wantsErr bool
+	}{
+		{
+			args:  []string{"-type", "a", "-name", "www", "-zone", "example.com", "-ip", "10.0.0.1"},
+			wants: dim.RR{Type: "A", Name: "www", Zone: "example.com", IP: "10.0.0.1"},
+		},
+		{
+			args:  []string{"-type", "TXT", "-name", "www.example.com.", "-view", "internal", "-txt", "a", "-txt", "b c"},
+			wants: dim.RR{Type: "TXT", Name: "www.example.com.", View: "internal", Strings: []string{"a", "b c"}},
+		},
+		{
+			args:  []string{"-type", "mx", "-name", "@", "-zone", "example.com", "-preference", "10", "-exchange", "mail.example.com."},
+			wants: dim.RR{Type: "MX", Name: "@", Zone: "example.com", Preference: int64Ptr(10), Exchange: "mail.example.com."},
+		},
+		{
+			args: []string{"-type", "SRV", "-name", "_sip._tcp", "-zone", "example.com",
+				"-priority", "0", "-weight", "5", "-port", "5060", "-target", "sip.example.com."},
+			wants: dim.RR{Type: "SRV", Name: "_sip._tcp", Zone: "example.com",
+				Priority: int64Ptr(0), Weight: int64Ptr(5), Port: int64Ptr(5060), Target: "sip.example.com."},
+		},
+		{
+			args:  []string{"-type", "caa", "-name", "@", "-zone", "example.com", "-field", "flags=0", "-field", "tag=issue"},
+			wants: dim.RR{Type: "CAA", Name: "@", Zone: "example.com", Fields: map[string]any{"flags": "0", "tag": "issue"}},
+		},
+		{args: []string{"-name", "www"}, wantsErr: true},
+		{args: []string{"-type", "A"}, wantsErr: true},
+		{args: []string{"-type", "MX", "-name", "@", "-preference", "ten"}, wantsErr: true},
+		{args: []string{"-type", "CAA", "-name", "@", "-field", "flags"}, wantsErr: true},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		fs := flag.NewFlagSet("rr create", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		rf := newRRFlags(fs)
+		err := fs.Parse(tests[i].args)
+		var rr dim.RR
+		if err == nil {
+			rr, err = rf.record(fs)
+		}
+		if tests[i].wantsErr {
+			if err == nil {
+				t.Errorf("record(%q) = %+v ; wants error", tests[i].args, rr)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(rr, tests[i].wants) {
+			t.Errorf("record(%q) = %+v, %v ; wants = %+v", tests[i].args, rr, err, tests[i].wants)
+		}
+	}
+}
+
+func TestRRCommands(t *testing.T) {
+	s, c, out := newTestCLI(t)
+	s.AddZone("example.com")
+
+	if err := run(c, "rr", "create", "-type", "a", "-name", "www", "-zone", "example.com", "-ip", "10.0.0.1", "-ttl", "600"); err != nil {
+		t.Fatalf("rr create error: %s", err)
+	}
+	got := fieldsOf(t, out)
+	if got["rr"] != "www A 10.0.0.1" || got["zone"] != "example.com" || got["TTL"] != "600" || got["created_by"] != dimtest.Username {
+		t.Errorf("rr create = %q", got)
+	}
+
+	err := run(c, "rr", "create", "-type", "A", "-name", "www", "-zone", "example.com", "-ip", "10.0.0.1")
+	var dimErr dim.Error
+	if !errors.As(err, &dimErr) || dimErr.Code != dimtest.CodeAlreadyExists {
+		t.Errorf("rr create of an existing record error = %v ; wants DIM error %d", err, dimtest.CodeAlreadyExists)
+	}
+
+	out.Reset()
+	if err := run(c, "rr", "list", "-zone", "example.com", "-type", "a"); err != nil {
+		t.Fatalf("rr list error: %s", err)
+	}
+	wants := "RECORD  ZONE         VIEW     TTL  TYPE  VALUE\n" +
+		"www     example.com  default  600  A     10.0.0.1\n"
+	if out.String() != wants {
+		t.Errorf("rr list = %q ; wants = %q", out, wants)
+	}
+
+	out.Reset()
+	if err := run(c, "rr", "delete", "-type", "A", "-name", "www", "-zone", "example.com", "-ip", "10.0.0.1"); err != nil {
+		t.Fatalf("rr delete error: %s", err)
+	}
+	if got := fieldsOf(t, out); got["result"] != "deleted" {
+		t.Errorf("rr delete = %q", got)
+	}
+
+	out.Reset()
+	if err := run(c, "rr", "list", "-zone", "example.com", "-o", "json"); err != nil {
+		t.Fatalf("rr list error: %s", err)
+	}
+	if out.String() != "[]\n" {
+		t.Errorf("rr list -o json = %q ; wants = %q", out, "[]\n")
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"terraform-provider-ionosdim/pkg/dim"
+)
+
+// zoneShow prints the attributes, the views and the SOA attributes of the zone
+func zoneShow(c *cli, cmd *command, args []string) error {
+	fs := c.flagSet(cmd)
+	var opts dim.ZoneViewOptions
+	fs.StringVar(&opts.View, "view", "", "view to show the SOA attributes of, may be omitted if the zone has a single view")
+	fs.BoolVar(&opts.Profile, "profile", false, "the zone is a zone profile")
+	positional, err := c.parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	dimC, err := c.dimClient()
+	if err != nil {
+		return err
+	}
+	zone := positional[0]
+	attrs, err := dimC.ZoneGetAttrs(c.ctx, zone, dim.ZoneOptions{Profile: opts.Profile})
+	if err != nil {
+		return err
+	}
+	v, err := object(attrs)
+	if err != nil {
+		return err
+	}
+	// profiles have no views
+	if !opts.Profile {
+		views, err := dimC.ZoneListViews(c.ctx, zone)
+		if err != nil {
+			return err
+		}
+		names := make([]string, len(views))
+		for i, view := range views {
+			names[i] = view.Name
+		}
+		v["views"] = names
+		if opts.View == "" && len(views) > 1 {
+			// zone_get_soa_attrs needs the view
+			return c.print(v, nil)
+		}
+	}
+	soa, err := dimC.ZoneGetSOAAttrs(c.ctx, zone, opts)
+	if err != nil {
+		return err
+	}
+	v["soa"] = soa
+	return c.print(v, nil)
+}
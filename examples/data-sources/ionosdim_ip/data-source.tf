@@ -0,0 +1,7 @@
+data "ionosdim_ip" "db_01" {
+  ip = "10.20.0.15"
+}
+
+output "db_01_gateway" {
+  value = data.ionosdim_ip.db_01.gateway
+}
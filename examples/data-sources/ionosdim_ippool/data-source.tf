@@ -0,0 +1,14 @@
+data "ionosdim_ippool" "dc1_servers" {
+  name = "dc1-servers"
+}
+
+resource "ionosdim_ip" "server_01" {
+  pool = data.ionosdim_ippool.dc1_servers.name
+
+  lifecycle {
+    precondition {
+      condition     = data.ionosdim_ippool.dc1_servers.free > 10
+      error_message = "The pool dc1-servers is running out of addresses."
+    }
+  }
+}
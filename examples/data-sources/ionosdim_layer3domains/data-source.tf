@@ -0,0 +1,5 @@
+data "ionosdim_layer3domains" "all" {}
+
+output "vrf_names" {
+  value = [for l3d in data.ionosdim_layer3domains.all.layer3domains : l3d.name if l3d.type == "vrf"]
+}
@@ -0,0 +1,9 @@
+data "ionosdim_records" "www" {
+  type    = "A"
+  pattern = "www*"
+  zone    = "example.com"
+}
+
+output "www_addresses" {
+  value = [for rr in data.ionosdim_records.www.records : rr.value]
+}
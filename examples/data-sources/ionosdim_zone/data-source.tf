@@ -0,0 +1,9 @@
+data "ionosdim_zone" "example" {
+  name            = "example.com"
+  view            = "internal"
+  include_records = true
+}
+
+output "nameservers" {
+  value = [for rr in data.ionosdim_zone.example.records : rr.value if rr.type == "NS" && rr.name == "@"]
+}
@@ -0,0 +1,6 @@
+# the address must be already allocated (Static) in DIM
+resource "ionosdim_aaaa_record" "record_01" {
+  name    = "some-host.example.com."
+  ip      = "2001:db8::11"
+  comment = "my comment"
+}
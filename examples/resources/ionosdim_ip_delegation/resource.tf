@@ -0,0 +1,12 @@
+# the next free /26 from the pool
+resource "ionosdim_ip_delegation" "node_01_pods" {
+  pool          = "k8s-pods"
+  prefix_length = 26
+  comment       = "pod CIDR of node-01"
+}
+
+# the specific block
+resource "ionosdim_ip_delegation" "vpn" {
+  pool  = "vpn-clients"
+  block = "10.30.4.0/24"
+}
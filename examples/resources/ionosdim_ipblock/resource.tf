@@ -0,0 +1,8 @@
+resource "ionosdim_ipblock" "dc1" {
+  block        = "10.20.0.0/16"
+  layer3domain = "default"
+  comment      = "address space of the dc1"
+  attributes = {
+    site = "dc1"
+  }
+}
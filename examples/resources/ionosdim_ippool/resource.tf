@@ -0,0 +1,9 @@
+resource "ionosdim_ippool" "dc1_servers" {
+  name         = "dc1-servers"
+  layer3domain = "default"
+  vlan         = 120
+  description  = "servers of the dc1"
+  attributes = {
+    team = "network"
+  }
+}
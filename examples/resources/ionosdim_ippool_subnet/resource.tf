@@ -0,0 +1,9 @@
+resource "ionosdim_ippool_subnet" "dc1_servers_01" {
+  pool    = ionosdim_ippool.dc1_servers.name
+  subnet  = "10.20.0.0/24"
+  gateway = "10.20.0.1"
+}
+
+resource "ionosdim_ip" "server_01" {
+  pool = ionosdim_ippool_subnet.dc1_servers_01.pool
+}
@@ -0,0 +1,6 @@
+resource "ionosdim_layer3domain" "customer_a" {
+  name    = "customer-a"
+  type    = "vrf"
+  rd      = "8560:100"
+  comment = "VRF of the customer A"
+}
@@ -0,0 +1,7 @@
+resource "ionosdim_mx_record" "mx_01" {
+  name       = "example.com."
+  preference = 10
+  exchange   = "mail-relay-01.example.com."
+  ttl        = 3600
+  comment    = "primary mail relay"
+}
@@ -0,0 +1,11 @@
+resource "ionosdim_ip" "ip_01" {
+  pool    = "some-pool"
+  comment = "my comment"
+}
+
+# the reverse zone and the layer3domain are determined from the ip
+resource "ionosdim_ptr_record" "record_01" {
+  ip       = ionosdim_ip.ip_01.ip
+  ptrdname = "some-host.example.com."
+  comment  = "my comment"
+}
@@ -0,0 +1,11 @@
+resource "ionosdim_record" "host_01_sshfp" {
+  name = "host-01"
+  zone = "example.com"
+  type = "SSHFP"
+  fields = {
+    algorithm        = "4"
+    fingerprint_type = "2"
+    fingerprint      = "4e2b6b5d7c0a2a1f5bd4a07e2ec1e4b3a3c0a2f2f3d1b8e7b9c6c3e2d1f0a9b8"
+  }
+  comment = "ssh host key fingerprint"
+}
@@ -0,0 +1,9 @@
+resource "ionosdim_srv_record" "ldap_01" {
+  name     = "_ldap._tcp"
+  zone     = "example.com"
+  priority = 10
+  weight   = 5
+  port     = 389
+  target   = "ldap-01.example.com."
+  comment  = "ldap service discovery"
+}
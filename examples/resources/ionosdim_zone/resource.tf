@@ -0,0 +1,11 @@
+resource "ionosdim_zone" "example" {
+  name    = "dev.example.com"
+  primary = "ns1.example.com."
+  mail    = "hostmaster.example.com."
+  refresh = 3600
+  retry   = 900
+  expire  = 604800
+  minimum = 300
+  ttl     = 3600
+  comment = "zone of the dev environment"
+}
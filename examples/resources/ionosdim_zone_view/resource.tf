@@ -0,0 +1,11 @@
+resource "ionosdim_zone_view" "internal" {
+  zone = "example.com"
+  name = "internal"
+}
+
+resource "ionosdim_a_record" "intranet" {
+  name = "intranet"
+  zone = ionosdim_zone_view.internal.zone
+  view = ionosdim_zone_view.internal.name
+  ip   = "10.0.0.10"
+}
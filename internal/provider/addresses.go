@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// prefixValidator validates that the value is a block in CIDR notation
+// without host bits, e.g. `10.0.0.0/23` but not `10.0.1.5/23`,
+// as DIM returns the block with the host bits cleared
+type prefixValidator struct{}
+
+func (v prefixValidator) Description(_ context.Context) string {
+	return "value must be a block in CIDR notation without host bits"
+}
+
+func (v prefixValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v prefixValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	s := req.ConfigValue.ValueString()
+	p, err := netip.ParsePrefix(s)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Block", fmt.Sprintf("%q is not a block in CIDR notation: %s", s, err))
+		return
+	}
+	if p != p.Masked() {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Block", fmt.Sprintf("%q has host bits set, the block is %s", s, p.Masked()))
+	}
+}
+
+// ipAddressValidator validates that the value is an IPv4 or IPv6 address
+type ipAddressValidator struct{}
+
+func (v ipAddressValidator) Description(_ context.Context) string {
+	return "value must be an IPv4 or IPv6 address"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	s := req.ConfigValue.ValueString()
+	if _, err := netip.ParseAddr(s); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IP Address", fmt.Sprintf("%q is not an IP address: %s", s, err))
+	}
+}
+
+// sameAddress reports whether a and b are the same address,
+// written differently, e.g. `2001:db8::1` and `2001:0db8::1`
+func sameAddress(a, b string) bool {
+	ipa, err := netip.ParseAddr(a)
+	if err != nil {
+		return false
+	}
+	ipb, err := netip.ParseAddr(b)
+	if err != nil {
+		return false
+	}
+	return ipa == ipb
+}
+
+// samePrefix reports whether a and b are the same block,
+// written differently, e.g. `2001:db8::/32` and `2001:0DB8::/32`
+func samePrefix(a, b string) bool {
+	pa, err := netip.ParsePrefix(a)
+	if err != nil {
+		return false
+	}
+	pb, err := netip.ParsePrefix(b)
+	if err != nil {
+		return false
+	}
+	return pa == pb
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPrefixValidator(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{input: "10.0.0.0/23", wantErr: false},
+		{input: "2001:db8::/32", wantErr: false},
+		{input: "10.0.1.5/23", wantErr: true},
+		{input: "2001:db8::1/32", wantErr: true},
+		{input: "10.0.0.0", wantErr: true},
+		{input: "not a block", wantErr: true},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		req := validator.StringRequest{Path: path.Root("block"), ConfigValue: types.StringValue(tests[i].input)}
+		var resp validator.StringResponse
+		prefixValidator{}.ValidateString(context.Background(), req, &resp)
+		if got := resp.Diagnostics.HasError(); got != tests[i].wantErr {
+			t.Errorf("prefixValidator(%q) error = %t ; wants = %t", tests[i].input, got, tests[i].wantErr)
+		}
+	}
+}
+
+func TestSamePrefix(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		wants bool
+	}{
+		{a: "10.0.0.0/23", b: "10.0.0.0/23", wants: true},
+		{a: "2001:0DB8::/32", b: "2001:db8::/32", wants: true},
+		{a: "10.0.0.0/23", b: "10.0.0.0/24", wants: false},
+		{a: "", b: "10.0.0.0/24", wants: false},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		if got := samePrefix(tests[i].a, tests[i].b); got != tests[i].wants {
+			t.Errorf("samePrefix(%q, %q) = %t ; wants = %t", tests[i].a, tests[i].b, got, tests[i].wants)
+		}
+	}
+}
+
+func TestIPAddressValidator(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{input: "10.0.0.1", wantErr: false},
+		{input: "2001:0db8::1", wantErr: false},
+		{input: "10.0.0.0/24", wantErr: true},
+		{input: "10.0.0.256", wantErr: true},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		req := validator.StringRequest{Path: path.Root("ip"), ConfigValue: types.StringValue(tests[i].input)}
+		var resp validator.StringResponse
+		ipAddressValidator{}.ValidateString(context.Background(), req, &resp)
+		if got := resp.Diagnostics.HasError(); got != tests[i].wantErr {
+			t.Errorf("ipAddressValidator(%q) error = %t ; wants = %t", tests[i].input, got, tests[i].wantErr)
+		}
+	}
+}
+
+func TestSameAddress(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		wants bool
+	}{
+		{a: "2001:db8::1", b: "2001:0db8::1", wants: true},
+		{a: "10.0.0.1", b: "10.0.0.1", wants: true},
+		{a: "10.0.0.1", b: "10.0.0.2", wants: false},
+		{a: "10.0.0.1", b: "::ffff:10.0.0.1", wants: false},
+		{a: "", b: "10.0.0.1", wants: false},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		if got := sameAddress(tests[i].a, tests[i].b); got != tests[i].wants {
+			t.Errorf("sameAddress(%q, %q) = %t ; wants = %t", tests[i].a, tests[i].b, got, tests[i].wants)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ipBlockReservedAttributes are the names of the DIM fields of an ip block,
+// which can not be used as user defined attributes
+var ipBlockReservedAttributes = []string{
+	"ip", "layer3domain", "created", "modified", "modified_by", "gateway",
+	"mask", "pool", "reverse_zone", "status", "subnet", "comment",
+}
+
+// ipPoolReservedAttributes are the names of the DIM fields of an ip pool,
+// which can not be used as user defined attributes
+var ipPoolReservedAttributes = []string{
+	"name", "vlan", "layer3domain", "created", "modified", "modified_by", "description",
+}
+
+// attributesFromMap converts the (user defined) DIM attributes from the model
+// to the form accepted by DIM, null or unknown map results in nil
+func attributesFromMap(ctx context.Context, m types.Map) (map[string]string, diag.Diagnostics) {
+	if m.IsNull() || m.IsUnknown() {
+		return nil, nil
+	}
+	attrs := map[string]string{}
+	diags := m.ElementsAs(ctx, &attrs, false)
+	return attrs, diags
+}
+
+// diffAttributes returns the attributes which has to be set
+// and the names of attributes which has to be deleted
+// to change the DIM attributes from old to new
+func diffAttributes(new, old map[string]string) (map[string]string, []string) {
+	set := map[string]string{}
+	for k, v := range new {
+		if ov, ok := old[k]; !ok || ov != v {
+			set[k] = v
+		}
+	}
+	var del []string
+	for k := range old {
+		if _, ok := new[k]; !ok {
+			del = append(del, k)
+		}
+	}
+	return set, del
+}
+
+// refreshAttributes refreshes the managed attributes m from the attributes returned by DIM,
+// only the keys already present in m are considered, so the attributes
+// set outside of terraform do not cause a diff
+func refreshAttributes(ctx context.Context, m types.Map, dimAttrs map[string]string) (types.Map, diag.Diagnostics) {
+	attrs, diags := attributesFromMap(ctx, m)
+	if diags.HasError() || attrs == nil {
+		return m, diags
+	}
+	refreshed := map[string]string{}
+	for k := range attrs {
+		if v, ok := dimAttrs[k]; ok {
+			refreshed[k] = v
+		}
+	}
+	return types.MapValueFrom(ctx, types.StringType, refreshed)
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestDiffAttributes(t *testing.T) {
+	tests := []struct {
+		new     map[string]string
+		old     map[string]string
+		wantSet map[string]string
+		wantDel []string
+	}{
+		{
+			new:     map[string]string{"a": "1", "b": "2"},
+			old:     nil,
+			wantSet: map[string]string{"a": "1", "b": "2"},
+			wantDel: nil,
+		},
+		{
+			new:     map[string]string{"a": "1", "b": "3"},
+			old:     map[string]string{"a": "1", "b": "2", "c": "3", "d": "4"},
+			wantSet: map[string]string{"b": "3"},
+			wantDel: []string{"c", "d"},
+		},
+		{
+			new:     nil,
+			old:     map[string]string{"a": "1"},
+			wantSet: map[string]string{},
+			wantDel: []string{"a"},
+		},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		gotSet, gotDel := diffAttributes(tests[i].new, tests[i].old)
+		sort.Strings(gotDel)
+		if !reflect.DeepEqual(gotSet, tests[i].wantSet) || !reflect.DeepEqual(gotDel, tests[i].wantDel) {
+			t.Errorf("diffAttributes(%v, %v) = %v, %v ; wants = %v, %v", tests[i].new, tests[i].old, gotSet, gotDel, tests[i].wantSet, tests[i].wantDel)
+		}
+	}
+}
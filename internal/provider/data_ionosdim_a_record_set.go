@@ -94,20 +94,20 @@ func (d *aRecordSetDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	dim_req_args := map[string]interface{}{"type": "A", "pattern": config.Host.ValueString()}
+	listOpts := dim.RRListOptions{Type: "A", Pattern: config.Host.ValueString()}
 
 	if !config.Layer3domain.IsNull() {
-		dim_req_args["layer3domain"] = config.Layer3domain.ValueString()
+		listOpts.Layer3domain = config.Layer3domain.ValueString()
 	}
 	if !config.View.IsNull() {
-		dim_req_args["view"] = config.View.ValueString()
+		listOpts.View = config.View.ValueString()
 	}
 	if !config.Zone.IsNull() {
-		dim_req_args["zone"] = config.Zone.ValueString()
+		listOpts.Zone = config.Zone.ValueString()
 	}
 
 	host := config.Host.ValueString()
-	dimRes, err := d.client.RawCallWithContext(ctx, "rr_list", []interface{}{dim_req_args})
+	dimRes, err := d.client.RRList(ctx, listOpts)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("error looking up A records for %q: ", host), err.Error())
 		return
@@ -117,10 +117,10 @@ func (d *aRecordSetDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	var view, layer3domain []string
 	//var ttl []int
 
-	for _, rr := range interface{}(dimRes).([]interface{}) {
-		addrs = append(addrs, (rr.(map[string]interface{}))["value"].(string))
-		view = append(view, (rr.(map[string]interface{}))["view"].(string))
-		layer3domain = append(layer3domain, (rr.(map[string]interface{}))["layer3domain"].(string))
+	for _, rr := range dimRes {
+		addrs = append(addrs, rr.Value)
+		view = append(view, rr.View)
+		layer3domain = append(layer3domain, rr.Layer3domain)
 	}
 	//sort.Strings(addrs)
 
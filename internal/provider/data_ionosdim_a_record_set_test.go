@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccARecordSetDataSource(t *testing.T) {
+	testAccDIM(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccARecordSetDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ionosdim_a_record_set.test", "id", "host"),
+					resource.TestCheckResourceAttr("data.ionosdim_a_record_set.test", "addrs.#", "2"),
+					resource.TestCheckResourceAttr("data.ionosdim_a_record_set.test", "addrs.0", "10.88.8.2"),
+					resource.TestCheckResourceAttr("data.ionosdim_a_record_set.test", "addrs.1", "10.88.8.3"),
+					resource.TestCheckResourceAttr("data.ionosdim_a_record_set.test", "view", "default"),
+					resource.TestCheckResourceAttr("data.ionosdim_a_record_set.test", "layer3domain", "default"),
+				),
+			},
+		},
+	})
+}
+
+var testAccARecordSetDataSourceConfig = fmt.Sprintf(`
+resource "ionosdim_ip" "test" {
+  count = 2
+  pool  = %[1]q
+}
+
+resource "ionosdim_a_record" "test" {
+  count        = 2
+  name         = "host"
+  zone         = %[2]q
+  layer3domain = ionosdim_ip.test[count.index].layer3domain
+  ip           = ionosdim_ip.test[count.index].ip
+}
+
+data "ionosdim_a_record_set" "test" {
+  host       = "host"
+  zone       = %[2]q
+  depends_on = [ionosdim_a_record.test]
+}
+`, testAccPool, testAccZone)
@@ -76,19 +76,19 @@ func (d *cnameRecordSetDataSource) Read(ctx context.Context, req datasource.Read
 	}
 
 	host := state.Host.ValueString()
-	dimRes, err := d.client.RawCallWithContext(ctx, "rr_list", []interface{}{map[string]interface{}{
-		"type":    "CNAME",
-		"pattern": host,
-		"fields":  true,
-	}})
+	dimRes, err := d.client.RRList(ctx, dim.RRListOptions{
+		Type:    "CNAME",
+		Pattern: host,
+		Fields:  true,
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("error looking up CNAME records for %q: ", host), err.Error())
 		return
 	}
 
 	addrs := []string{}
-	for _, rr := range interface{}(dimRes).([]interface{}) {
-		addrs = append(addrs, (rr.(map[string]interface{}))["value"].(string))
+	for _, rr := range dimRes {
+		addrs = append(addrs, rr.Value)
 	}
 	//sort.Strings(addrs)
 
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCNAMERecordSetDataSource(t *testing.T) {
+	testAccDIM(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccCNAMERecordSetDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ionosdim_cname_record_set.test", "id", "www"),
+					resource.TestCheckResourceAttr("data.ionosdim_cname_record_set.test", "addrs.#", "1"),
+					resource.TestCheckResourceAttr("data.ionosdim_cname_record_set.test", "addrs.0", "host.example.com."),
+				),
+			},
+		},
+	})
+}
+
+var testAccCNAMERecordSetDataSourceConfig = fmt.Sprintf(`
+resource "ionosdim_cname_record" "test" {
+  name  = "www"
+  zone  = %[1]q
+  cname = "host.example.com."
+}
+
+data "ionosdim_cname_record_set" "test" {
+  host       = "www"
+  depends_on = [ionosdim_cname_record.test]
+}
+`, testAccZone)
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ipDataSource{}
+	_ datasource.DataSourceWithConfigure = &ipDataSource{}
+)
+
+func NewIpDataSource() datasource.DataSource {
+	return &ipDataSource{}
+}
+
+type ipDataSource struct {
+	client *dim.Client
+}
+
+// data model
+type ipDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Ip           types.String `tfsdk:"ip"`
+	Layer3domain types.String `tfsdk:"layer3domain"`
+
+	Status      types.String `tfsdk:"status"`
+	Pool        types.String `tfsdk:"pool"`
+	Subnet      types.String `tfsdk:"subnet"`
+	Gateway     types.String `tfsdk:"gateway"`
+	Mask        types.String `tfsdk:"mask"`
+	ReverseZone types.String `tfsdk:"reverse_zone"`
+	Comment     types.String `tfsdk:"comment"`
+	Attributes  types.Map    `tfsdk:"attributes"`
+	Created     types.String `tfsdk:"created"` // no created_by field!
+	Modified    types.String `tfsdk:"modified"`
+	ModifiedBy  types.String `tfsdk:"modified_by"`
+}
+
+// stringValueOrNull returns the null String for the empty string
+func stringValueOrNull(v string) types.String {
+	if v == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(v)
+}
+
+// Metadata returns the data source type name.
+func (d *ipDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip"
+}
+
+// Schema defines the schema for the data source.
+func (d *ipDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the attributes of an ip address.",
+		Attributes: map[string]schema.Attribute{
+			"ip": schema.StringAttribute{
+				Required:    true,
+				Description: "IP address to look up.",
+			},
+			"layer3domain": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The layer 3 domain of the address, optional if DIM has a single layer 3 domain.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the address, e.g. `Static` or `Available`.",
+			},
+			"pool": schema.StringAttribute{
+				Computed: true,
+			},
+			"subnet": schema.StringAttribute{
+				Computed: true,
+			},
+			"gateway": schema.StringAttribute{
+				Computed: true,
+			},
+			"mask": schema.StringAttribute{
+				Computed: true,
+			},
+			"reverse_zone": schema.StringAttribute{
+				Computed: true,
+			},
+			"comment": schema.StringAttribute{
+				Computed: true,
+			},
+			"attributes": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The user defined attributes of the address.",
+			},
+			"created": schema.StringAttribute{
+				Computed: true,
+			},
+			"modified": schema.StringAttribute{
+				Computed: true,
+			},
+			"modified_by": schema.StringAttribute{
+				Computed: true,
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Set to `<layer3domain>/<ip>`.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ipDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ipDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ip := state.Ip.ValueString()
+	dimRes, err := d.client.IPBlockGetAttrs(ctx, ip, dim.IPBlockOptions{
+		Host:         true,
+		Layer3domain: state.Layer3domain.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error looking up ip %q: ", ip), err.Error())
+		return
+	}
+
+	state.Layer3domain = types.StringValue(dimRes.Layer3domain)
+	state.Status = stringValueOrNull(dimRes.Status)
+	state.Pool = stringValueOrNull(dimRes.Pool)
+	state.Subnet = stringValueOrNull(dimRes.Subnet)
+	state.Gateway = stringValueOrNull(dimRes.Gateway)
+	state.Mask = stringValueOrNull(dimRes.Mask)
+	state.ReverseZone = stringValueOrNull(dimRes.ReverseZone)
+	state.Comment = types.StringPointerValue(dimRes.Comment)
+	state.Created = stringValueOrNull(dimRes.Created)
+	state.Modified = stringValueOrNull(dimRes.Modified)
+	state.ModifiedBy = stringValueOrNull(dimRes.ModifiedBy)
+
+	var convertDiags diag.Diagnostics
+	state.Attributes, convertDiags = types.MapValueFrom(ctx, types.StringType, dimRes.Attributes)
+	resp.Diagnostics.Append(convertDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(dimRes.Layer3domain + "/" + ip)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ipDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,249 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ippoolDataSource{}
+	_ datasource.DataSourceWithConfigure = &ippoolDataSource{}
+)
+
+func NewIPPoolDataSource() datasource.DataSource {
+	return &ippoolDataSource{}
+}
+
+type ippoolDataSource struct {
+	client *dim.Client
+}
+
+// data model
+type ippoolDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Layer3domain types.String `tfsdk:"layer3domain"`
+	VLAN         types.Int64  `tfsdk:"vlan"`
+	Description  types.String `tfsdk:"description"`
+	Attributes   types.Map    `tfsdk:"attributes"`
+	Subnets      types.List   `tfsdk:"subnets"`
+	Total        types.Int64  `tfsdk:"total"`
+	Used         types.Int64  `tfsdk:"used"`
+	Free         types.Int64  `tfsdk:"free"`
+}
+
+type ippoolDataSourceSubnetModel struct {
+	Subnet  types.String `tfsdk:"subnet"`
+	Gateway types.String `tfsdk:"gateway"`
+	Mask    types.String `tfsdk:"mask"`
+	Total   types.Int64  `tfsdk:"total"`
+	Used    types.Int64  `tfsdk:"used"`
+	Free    types.Int64  `tfsdk:"free"`
+}
+
+var ippoolDataSourceSubnetType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"subnet":  types.StringType,
+		"gateway": types.StringType,
+		"mask":    types.StringType,
+		"total":   types.Int64Type,
+		"used":    types.Int64Type,
+		"free":    types.Int64Type,
+	},
+}
+
+// Metadata returns the data source type name.
+func (d *ippoolDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ippool"
+}
+
+// Schema defines the schema for the data source.
+func (d *ippoolDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the ip pool, its subnets and the address usage.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Pool to look up.",
+			},
+			"layer3domain": schema.StringAttribute{
+				Computed: true,
+			},
+			"vlan": schema.Int64Attribute{
+				Computed: true,
+			},
+			"description": schema.StringAttribute{
+				Computed: true,
+			},
+			"attributes": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The user defined attributes of the pool.",
+			},
+			"subnets": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "A list of the subnets of the pool.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"subnet": schema.StringAttribute{
+							Computed:    true,
+							Description: "The subnet in CIDR notation.",
+						},
+						"gateway": schema.StringAttribute{
+							Computed: true,
+						},
+						"mask": schema.StringAttribute{
+							Computed: true,
+						},
+						"total": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of addresses in the subnet.",
+						},
+						"used": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of not free addresses in the subnet.",
+						},
+						"free": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of free addresses in the subnet.",
+						},
+					},
+				},
+			},
+			"total": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of addresses in all subnets of the pool.",
+			},
+			"used": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of not free addresses in all subnets of the pool.",
+			},
+			"free": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of free addresses in all subnets of the pool.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Always set to the name.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ippoolDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ippoolDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := state.Name.ValueString()
+	dimPool, err := d.client.IPPoolGetAttrs(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error looking up pool %q: ", name), err.Error())
+		return
+	}
+	dimSubnets, err := d.client.IPList(ctx, dim.IPListOptions{
+		Pool:         name,
+		Type:         "subnets",
+		Layer3domain: dimPool.Layer3domain,
+		Full:         true,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error listing subnets of pool %q: ", name), err.Error())
+		return
+	}
+
+	state.Layer3domain = types.StringValue(dimPool.Layer3domain)
+	state.VLAN = types.Int64PointerValue(dimPool.VLAN)
+	state.Description = types.StringPointerValue(dimPool.Description)
+
+	var convertDiags diag.Diagnostics
+	state.Attributes, convertDiags = types.MapValueFrom(ctx, types.StringType, dimPool.Attributes)
+	resp.Diagnostics.Append(convertDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the pool counts are known only if the counts of all subnets are known
+	var total, free int64
+	countsKnown := true
+	subnets := []ippoolDataSourceSubnetModel{}
+	for _, s := range dimSubnets {
+		subnet := ippoolDataSourceSubnetModel{
+			Subnet:  types.StringValue(s.IP),
+			Gateway: types.StringNull(),
+			Mask:    types.StringNull(),
+			Total:   types.Int64PointerValue(s.Total),
+			Used:    types.Int64Null(),
+			Free:    types.Int64PointerValue(s.Free),
+		}
+		if s.Gateway != "" {
+			subnet.Gateway = types.StringValue(s.Gateway)
+		}
+		if s.Mask != "" {
+			subnet.Mask = types.StringValue(s.Mask)
+		}
+		if s.Total != nil && s.Free != nil {
+			subnet.Used = types.Int64Value(*s.Total - *s.Free)
+			total += *s.Total
+			free += *s.Free
+		} else {
+			countsKnown = false
+		}
+		subnets = append(subnets, subnet)
+	}
+	state.Subnets, convertDiags = types.ListValueFrom(ctx, ippoolDataSourceSubnetType, subnets)
+	resp.Diagnostics.Append(convertDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if countsKnown {
+		state.Total = types.Int64Value(total)
+		state.Used = types.Int64Value(total - free)
+		state.Free = types.Int64Value(free)
+	} else {
+		state.Total = types.Int64Null()
+		state.Used = types.Int64Null()
+		state.Free = types.Int64Null()
+	}
+
+	state.ID = state.Name
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ippoolDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
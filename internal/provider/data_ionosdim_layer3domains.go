@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &layer3domainsDataSource{}
+	_ datasource.DataSourceWithConfigure = &layer3domainsDataSource{}
+)
+
+func NewLayer3domainsDataSource() datasource.DataSource {
+	return &layer3domainsDataSource{}
+}
+
+type layer3domainsDataSource struct {
+	client *dim.Client
+}
+
+// data model
+type layer3domainsDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Layer3domains types.List   `tfsdk:"layer3domains"`
+}
+
+type layer3domainsDataSourceItemModel struct {
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	RD      types.String `tfsdk:"rd"`
+	Comment types.String `tfsdk:"comment"`
+}
+
+var layer3domainsDataSourceItemType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":    types.StringType,
+		"type":    types.StringType,
+		"rd":      types.StringType,
+		"comment": types.StringType,
+	},
+}
+
+// Metadata returns the data source type name.
+func (d *layer3domainsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_layer3domains"
+}
+
+// Schema defines the schema for the data source.
+func (d *layer3domainsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to list the layer 3 domains.",
+		Attributes: map[string]schema.Attribute{
+			"layer3domains": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "A list of the layer 3 domains.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"type": schema.StringAttribute{
+							Computed: true,
+						},
+						"rd": schema.StringAttribute{
+							Computed:    true,
+							Description: "The route distinguisher.",
+						},
+						"comment": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Always set to `layer3domains`.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *layer3domainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state layer3domainsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dimRes, err := d.client.Layer3domainList(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("error listing layer3domains: ", err.Error())
+		return
+	}
+
+	items := []layer3domainsDataSourceItemModel{}
+	for _, l3d := range dimRes {
+		item := layer3domainsDataSourceItemModel{
+			Name:    types.StringValue(l3d.Name),
+			Type:    types.StringValue(l3d.Type),
+			RD:      types.StringNull(),
+			Comment: types.StringPointerValue(l3d.Comment),
+		}
+		if l3d.RD != "" {
+			item.RD = types.StringValue(l3d.RD)
+		}
+		items = append(items, item)
+	}
+
+	var convertDiags diag.Diagnostics
+	state.Layer3domains, convertDiags = types.ListValueFrom(ctx, layer3domainsDataSourceItemType, items)
+	resp.Diagnostics.Append(convertDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue("layer3domains")
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *layer3domainsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
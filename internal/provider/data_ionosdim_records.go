@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &recordsDataSource{}
+	_ datasource.DataSourceWithConfigure        = &recordsDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &recordsDataSource{}
+)
+
+func NewRecordsDataSource() datasource.DataSource {
+	return &recordsDataSource{}
+}
+
+type recordsDataSource struct {
+	client *dim.Client
+}
+
+// data model
+type recordsDataSourceModel struct {
+	ID types.String `tfsdk:"id"`
+	// filters
+	Type         types.String `tfsdk:"type"`
+	Pattern      types.String `tfsdk:"pattern"`
+	Zone         types.String `tfsdk:"zone"`
+	View         types.String `tfsdk:"view"`
+	Layer3domain types.String `tfsdk:"layer3domain"`
+	CreatedBy    types.String `tfsdk:"created_by"`
+	Comment      types.String `tfsdk:"comment"`
+
+	Records types.List `tfsdk:"records"`
+}
+
+type recordsDataSourceRecordModel struct {
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	Value   types.String `tfsdk:"value"`
+	TTL     types.Int64  `tfsdk:"ttl"`
+	View    types.String `tfsdk:"view"`
+	Zone    types.String `tfsdk:"zone"`
+	Comment types.String `tfsdk:"comment"`
+}
+
+var recordsDataSourceRecordType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":    types.StringType,
+		"type":    types.StringType,
+		"value":   types.StringType,
+		"ttl":     types.Int64Type,
+		"view":    types.StringType,
+		"zone":    types.StringType,
+		"comment": types.StringType,
+	},
+}
+
+func newRecordsDataSourceRecordModel(rr dim.RRListItem) recordsDataSourceRecordModel {
+	return recordsDataSourceRecordModel{
+		Name:    types.StringValue(rr.Record),
+		Type:    types.StringValue(rr.Type),
+		Value:   types.StringValue(rr.Value),
+		TTL:     types.Int64PointerValue(rr.TTL),
+		View:    stringValueOrNull(rr.View),
+		Zone:    stringValueOrNull(rr.Zone),
+		Comment: stringValueOrNull(rr.Comment),
+	}
+}
+
+// rrListOptions returns the rr_list filters set in the model
+func (m recordsDataSourceModel) rrListOptions() dim.RRListOptions {
+	return dim.RRListOptions{
+		Type:         m.Type.ValueString(),
+		Pattern:      m.Pattern.ValueString(),
+		Zone:         m.Zone.ValueString(),
+		View:         m.View.ValueString(),
+		Layer3domain: m.Layer3domain.ValueString(),
+		CreatedBy:    m.CreatedBy.ValueString(),
+		Comment:      m.Comment.ValueString(),
+		Fields:       true,
+	}
+}
+
+// Metadata returns the data source type name.
+func (d *recordsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_records"
+}
+
+// ConfigValidators requires at least one filter, so that not all the records of DIM are listed.
+func (d *recordsDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(
+			path.MatchRoot("type"),
+			path.MatchRoot("pattern"),
+			path.MatchRoot("zone"),
+			path.MatchRoot("view"),
+			path.MatchRoot("layer3domain"),
+			path.MatchRoot("created_by"),
+			path.MatchRoot("comment"),
+		),
+	}
+}
+
+// Schema defines the schema for the data source.
+func (d *recordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to list the DNS records matching the filters, at least one filter must be set.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only the records of this type, e.g. `A`.",
+			},
+			"pattern": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only the records with the name matching the pattern, `*` matches any characters.",
+			},
+			"zone": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only the records of the zone.",
+			},
+			"view": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only the records of the view.",
+			},
+			"layer3domain": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only the records referring the addresses of the layer 3 domain.",
+			},
+			"created_by": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only the records created by the user.",
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only the records with the comment.",
+			},
+			"records": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "A list of the matching records.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the record relative to the zone.",
+						},
+						"type": schema.StringAttribute{
+							Computed: true,
+						},
+						"value": schema.StringAttribute{
+							Computed:    true,
+							Description: "The value of the record, e.g. the ip address of an A record.",
+						},
+						"ttl": schema.Int64Attribute{
+							Computed: true,
+						},
+						"view": schema.StringAttribute{
+							Computed: true,
+						},
+						"zone": schema.StringAttribute{
+							Computed: true,
+						},
+						"comment": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Always set to `records`.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *recordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state recordsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dimRes, err := d.client.RRList(ctx, state.rrListOptions())
+	if err != nil {
+		resp.Diagnostics.AddError("error listing records: ", err.Error())
+		return
+	}
+
+	records := []recordsDataSourceRecordModel{}
+	for _, rr := range dimRes {
+		records = append(records, newRecordsDataSourceRecordModel(rr))
+	}
+
+	var convertDiags diag.Diagnostics
+	state.Records, convertDiags = types.ListValueFrom(ctx, recordsDataSourceRecordType, records)
+	resp.Diagnostics.Append(convertDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue("records")
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *recordsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
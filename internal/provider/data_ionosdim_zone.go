@@ -0,0 +1,303 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &zoneDataSource{}
+	_ datasource.DataSourceWithConfigure = &zoneDataSource{}
+)
+
+func NewZoneDataSource() datasource.DataSource {
+	return &zoneDataSource{}
+}
+
+type zoneDataSource struct {
+	client *dim.Client
+}
+
+// data model
+type zoneDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	IsProfile      types.Bool   `tfsdk:"is_profile"`
+	View           types.String `tfsdk:"view"`
+	IncludeRecords types.Bool   `tfsdk:"include_records"`
+
+	Primary types.String `tfsdk:"primary"`
+	Mail    types.String `tfsdk:"mail"`
+	Serial  types.Int64  `tfsdk:"serial"`
+	Refresh types.Int64  `tfsdk:"refresh"`
+	Retry   types.Int64  `tfsdk:"retry"`
+	Expire  types.Int64  `tfsdk:"expire"`
+	Minimum types.Int64  `tfsdk:"minimum"`
+	TTL     types.Int64  `tfsdk:"ttl"`
+
+	Comment    types.String `tfsdk:"comment"`
+	Created    types.String `tfsdk:"created"`
+	CreatedBy  types.String `tfsdk:"created_by"`
+	Modified   types.String `tfsdk:"modified"`
+	ModifiedBy types.String `tfsdk:"modified_by"`
+
+	Views      types.List `tfsdk:"views"`
+	ZoneGroups types.List `tfsdk:"zone_groups"`
+	Records    types.List `tfsdk:"records"`
+}
+
+// Metadata returns the data source type name.
+func (d *zoneDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone"
+}
+
+// Schema defines the schema for the data source.
+func (d *zoneDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the zone, its SOA attributes, views, zone groups and optionally its records.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Zone to look up.",
+			},
+			"is_profile": schema.BoolAttribute{
+				Optional: true,
+				Description: "Whether `name` is a zone profile instead of a zone. " +
+					"DIM does not return the profile a zone was created from, so it can not be read for a zone.",
+			},
+			"view": schema.StringAttribute{
+				Optional:    true,
+				Description: "The view the SOA attributes and the records are read from, optional if the zone has a single view.",
+			},
+			"include_records": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to list the records of the view in `records`.",
+			},
+
+			"primary": schema.StringAttribute{
+				Computed:    true,
+				Description: "The SOA primary name server.",
+			},
+			"mail": schema.StringAttribute{
+				Computed:    true,
+				Description: "The SOA responsible mailbox.",
+			},
+			"serial": schema.Int64Attribute{
+				Computed: true,
+			},
+			"refresh": schema.Int64Attribute{
+				Computed: true,
+			},
+			"retry": schema.Int64Attribute{
+				Computed: true,
+			},
+			"expire": schema.Int64Attribute{
+				Computed: true,
+			},
+			"minimum": schema.Int64Attribute{
+				Computed: true,
+			},
+			"ttl": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The default TTL of the records in the zone.",
+			},
+
+			"comment": schema.StringAttribute{
+				Computed: true,
+			},
+			"created": schema.StringAttribute{
+				Computed: true,
+			},
+			"created_by": schema.StringAttribute{
+				Computed: true,
+			},
+			"modified": schema.StringAttribute{
+				Computed: true,
+			},
+			"modified_by": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"views": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "A list of the names of the views of the zone.",
+			},
+			"zone_groups": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "A list of the zone groups the view belongs to.",
+			},
+			"records": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "A list of the records of the view, set only if `include_records` is true.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the record relative to the zone.",
+						},
+						"type": schema.StringAttribute{
+							Computed: true,
+						},
+						"value": schema.StringAttribute{
+							Computed: true,
+						},
+						"ttl": schema.Int64Attribute{
+							Computed: true,
+						},
+						"view": schema.StringAttribute{
+							Computed: true,
+						},
+						"zone": schema.StringAttribute{
+							Computed: true,
+						},
+						"comment": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Always set to the name.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *zoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state zoneDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := state.Name.ValueString()
+	profile := state.IsProfile.ValueBool()
+	view := state.View.ValueString()
+
+	dimZone, err := d.client.ZoneGetAttrs(ctx, name, dim.ZoneOptions{Profile: profile})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error looking up zone %q: ", name), err.Error())
+		return
+	}
+	dimSOA, err := d.client.ZoneGetSOAAttrs(ctx, name, dim.ZoneViewOptions{Profile: profile, View: view})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error looking up SOA of zone %q: ", name), err.Error())
+		return
+	}
+	dimViews, err := d.client.ZoneListViews(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error listing views of zone %q: ", name), err.Error())
+		return
+	}
+
+	state.Primary = stringValueOrNull(dimSOA.Primary)
+	state.Mail = stringValueOrNull(dimSOA.Mail)
+	state.Serial = types.Int64PointerValue(dimSOA.Serial)
+	state.Refresh = types.Int64PointerValue(dimSOA.Refresh)
+	state.Retry = types.Int64PointerValue(dimSOA.Retry)
+	state.Expire = types.Int64PointerValue(dimSOA.Expire)
+	state.Minimum = types.Int64PointerValue(dimSOA.Minimum)
+	state.TTL = types.Int64PointerValue(dimSOA.TTL)
+
+	state.Comment = types.StringPointerValue(dimZone.Comment)
+	state.Created = stringValueOrNull(dimZone.Created)
+	state.CreatedBy = stringValueOrNull(dimZone.CreatedBy)
+	state.Modified = stringValueOrNull(dimZone.Modified)
+	state.ModifiedBy = stringValueOrNull(dimZone.ModifiedBy)
+
+	views := []string{}
+	for _, v := range dimViews {
+		views = append(views, v.Name)
+	}
+	// the selected view, or the single view of the zone
+	if view == "" && len(views) == 1 {
+		view = views[0]
+	}
+
+	zoneGroups := []string{}
+	if !profile {
+		// zone profiles are not in zone groups
+		dimZoneGroups, err := d.client.ZoneListZoneGroups(ctx, name)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("error listing zone groups of zone %q: ", name), err.Error())
+			return
+		}
+		for _, zg := range dimZoneGroups {
+			if zg.View == view {
+				zoneGroups = append(zoneGroups, zg.ZoneGroup)
+			}
+		}
+	}
+
+	var convertDiags diag.Diagnostics
+	state.Views, convertDiags = types.ListValueFrom(ctx, types.StringType, views)
+	resp.Diagnostics.Append(convertDiags...)
+	state.ZoneGroups, convertDiags = types.ListValueFrom(ctx, types.StringType, zoneGroups)
+	resp.Diagnostics.Append(convertDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Records = types.ListNull(recordsDataSourceRecordType)
+	if state.IncludeRecords.ValueBool() {
+		dimRecords, err := d.client.RRList(ctx, dim.RRListOptions{
+			Zone:   name,
+			View:   view,
+			Fields: true,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("error listing records of zone %q: ", name), err.Error())
+			return
+		}
+		records := []recordsDataSourceRecordModel{}
+		for _, rr := range dimRecords {
+			records = append(records, newRecordsDataSourceRecordModel(rr))
+		}
+		state.Records, convertDiags = types.ListValueFrom(ctx, recordsDataSourceRecordType, records)
+		resp.Diagnostics.Append(convertDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	state.ID = state.Name
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *zoneDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
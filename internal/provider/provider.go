@@ -5,16 +5,22 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"terraform-provider-ionosdim/pkg/dim"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -36,6 +42,17 @@ type ionosdimProviderModel struct {
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
 	Token    types.String `tfsdk:"token"`
+
+	MaxRetries   types.Int64 `tfsdk:"max_retries"`
+	RetryWaitMin types.Int64 `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.Int64 `tfsdk:"retry_wait_max"`
+
+	Timeout            types.Int64  `tfsdk:"timeout"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
 }
 
 func (p *ionosdimProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -51,11 +68,11 @@ func (p *ionosdimProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Optional:            true,
 			},
 			"username": schema.StringAttribute{
-				MarkdownDescription: "DIM username, it is ignored if `token` is specified. Can be sourced from `IONOSDIM_USERNAME` environment variable.",
+				MarkdownDescription: "DIM username, used to login if `token` is not specified or when the session expires. Can be sourced from `IONOSDIM_USERNAME` environment variable.",
 				Optional:            true,
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "DIM user password, it is ignored if `token` is specified. Can be sourced from `IONOSDIM_PASSWORD` environment variable.",
+				MarkdownDescription: "DIM user password, used to login if `token` is not specified or when the session expires. Can be sourced from `IONOSDIM_PASSWORD` environment variable.",
 				Optional:            true,
 				Sensitive:           true,
 			},
@@ -64,6 +81,61 @@ func (p *ionosdimProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of retries of a failed DIM call, defaults to 3. " +
+					"Only the reading calls are retried after a transport error or a 429/502/503/504 response, any call is retried if DIM could not be connected.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_min": schema.Int64Attribute{
+				MarkdownDescription: "Minimum wait in seconds before retrying a failed DIM call, defaults to 1. The wait doubles with every retry.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_max": schema.Int64Attribute{
+				MarkdownDescription: "Maximum wait in seconds before retrying a failed DIM call, defaults to 30.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "Timeout in seconds of a DIM request, defaults to 10.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the PEM bundle of the CA certificates trusted in addition to the system ones.",
+				Optional:            true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the PEM client certificate for mutual TLS authentication.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_file")),
+				},
+			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the PEM key of `client_cert_file`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_file")),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Disables the verification of the DIM certificate.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the http proxy to DIM, e.g. http://proxy.example.com:3128 . The `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables are used if not specified.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -183,6 +255,26 @@ func (p *ionosdimProvider) Configure(ctx context.Context, req provider.Configure
 		}
 	}
 
+	maxRetries := int64(dim.DefaultMaxRetries)
+	retryWaitMin := int64(dim.DefaultRetryWaitMin / time.Second)
+	retryWaitMax := int64(dim.DefaultRetryWaitMax / time.Second)
+	if !config.MaxRetries.IsNull() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+	if !config.RetryWaitMin.IsNull() {
+		retryWaitMin = config.RetryWaitMin.ValueInt64()
+	}
+	if !config.RetryWaitMax.IsNull() {
+		retryWaitMax = config.RetryWaitMax.ValueInt64()
+	}
+	if retryWaitMin > retryWaitMax {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_max"),
+			"Invalid IonosDim API Retry Wait",
+			"The retry_wait_max value must not be less than the retry_wait_min value.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -194,7 +286,18 @@ func (p *ionosdimProvider) Configure(ctx context.Context, req provider.Configure
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "password", "token")
 	tflog.Debug(ctx, "Creating IonosDim API Client")
 	// Create a new HashiCups client using the configuration values
-	client, err := dim.NewClientWithContext(ctx, &endpoint, &token, &username, &password, nil)
+	client, err := dim.NewClientWithContext(ctx, &endpoint, &token, &username, &password, nil,
+		dim.WithRetry(int(maxRetries), time.Duration(retryWaitMin)*time.Second, time.Duration(retryWaitMax)*time.Second),
+		dim.WithCallLogger(logDimCall),
+		dim.WithTransport(dim.TransportOptions{
+			Timeout:            time.Duration(config.Timeout.ValueInt64()) * time.Second,
+			CACertFile:         config.CACertFile.ValueString(),
+			ClientCertFile:     config.ClientCertFile.ValueString(),
+			ClientKeyFile:      config.ClientKeyFile.ValueString(),
+			InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+			ProxyURL:           config.ProxyURL.ValueString(),
+		}),
+	)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create IonosDim API Client",
@@ -217,8 +320,20 @@ func (p *ionosdimProvider) Resources(ctx context.Context) []func() resource.Reso
 	return []func() resource.Resource{
 		NewIpResource,
 		NewARecordResource,
+		NewAAAARecordResource,
 		NewCNAMERecordResource,
 		NewTXTRecordResource,
+		NewPTRRecordResource,
+		NewMXRecordResource,
+		NewSRVRecordResource,
+		NewRecordResource,
+		NewZoneResource,
+		NewZoneViewResource,
+		NewIPPoolResource,
+		NewIPPoolSubnetResource,
+		NewIPBlockResource,
+		NewIPDelegationResource,
+		NewLayer3domainResource,
 	}
 }
 
@@ -226,6 +341,11 @@ func (p *ionosdimProvider) DataSources(ctx context.Context) []func() datasource.
 	return []func() datasource.DataSource{
 		NewARecordSetDataSource,
 		NewCNAMERecordSetDataSource,
+		NewLayer3domainsDataSource,
+		NewIPPoolDataSource,
+		NewIpDataSource,
+		NewRecordsDataSource,
+		NewZoneDataSource,
 	}
 }
 
@@ -236,3 +356,13 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// logDimCall logs the DIM function calls of the resources and data sources
+// with their arguments and the DIM response for debugging
+func logDimCall(ctx context.Context, function string, args any, result json.RawMessage, err error) {
+	fields := map[string]any{"func": function, "args": args, "dimResponse": string(result)}
+	if err != nil {
+		fields["err"] = err.Error()
+	}
+	tflog.Debug(ctx, fmt.Sprintf("%s dim-call", function), fields)
+}
@@ -4,10 +4,18 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
+	"terraform-provider-ionosdim/pkg/dim"
+	"terraform-provider-ionosdim/pkg/dim/dimtest"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -15,11 +23,76 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
+	"ionosdim": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// the pool and the zone set up in the fake DIM of the acceptance tests
+const (
+	testAccPool   = "test-pool"
+	testAccSubnet = "10.88.8.0/24"
+	testAccZone   = "example.com"
+)
+
 func testAccPreCheck(t *testing.T) {
 	// You can add code here to run prior to any test case execution, for example assertions
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+// testAccDIM starts a fake DIM with testAccPool and testAccZone
+// and points the provider to it via the environment variables
+func testAccDIM(t *testing.T) *dimtest.Server {
+	t.Helper()
+	s := dimtest.NewServer()
+	t.Cleanup(s.Close)
+	if err := s.AddPool(testAccPool, testAccSubnet); err != nil {
+		t.Fatal(err)
+	}
+	s.AddZone(testAccZone)
+
+	t.Setenv("IONOSDIM_ENDPOINT", s.URL)
+	t.Setenv("IONOSDIM_USERNAME", dimtest.Username)
+	t.Setenv("IONOSDIM_PASSWORD", dimtest.Password)
+	t.Setenv("IONOSDIM_TOKEN", "")
+	return s
+}
+
+// testAccDIMClient returns a client of the fake DIM,
+// e.g. to change the objects managed by Terraform behind its back
+func testAccDIMClient(t *testing.T, s *dimtest.Server) *dim.Client {
+	t.Helper()
+	endpoint, token, username, password := s.URL, s.Login(), "", ""
+	c, err := dim.NewClient(&endpoint, &token, &username, &password, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// testResourceCreate calls Create of the resource configured with the client
+// for the planned model, without the Terraform CLI, and reads the state into the model state
+func testResourceCreate(t *testing.T, r resource.Resource, c *dim.Client, plan, state any) diag.Diagnostics {
+	t.Helper()
+	ctx := context.Background()
+	if r, ok := r.(resource.ResourceWithConfigure); ok {
+		var resp resource.ConfigureResponse
+		r.Configure(ctx, resource.ConfigureRequest{ProviderData: c}, &resp)
+		if resp.Diagnostics.HasError() {
+			return resp.Diagnostics
+		}
+	}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx)
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, nil)}}
+	if diags := req.Plan.Set(ctx, plan); diags.HasError() {
+		t.Fatalf("Plan.Set() error: %v", diags)
+	}
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, nil)}}
+	r.Create(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		return resp.Diagnostics
+	}
+	return resp.State.Get(ctx, state)
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccARecordResource(t *testing.T) {
+	s := testAccDIM(t)
+	c := testAccDIMClient(t, s)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckNoRecords(c, "A"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccARecordResourceConfig("host", 300, "some comment"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ionosdim_a_record.test", "id", "example.com//host/default/10.88.8.2"),
+					resource.TestCheckResourceAttr("ionosdim_a_record.test", "zone", testAccZone),
+					resource.TestCheckResourceAttr("ionosdim_a_record.test", "ip", "10.88.8.2"),
+					resource.TestCheckResourceAttr("ionosdim_a_record.test", "ttl", "300"),
+					resource.TestCheckResourceAttr("ionosdim_a_record.test", "comment", "some comment"),
+					resource.TestCheckResourceAttr("ionosdim_a_record.test", "rr", "host A 10.88.8.2"),
+				),
+			},
+			// Update in place testing
+			{
+				Config: testAccARecordResourceConfig("host", 600, "other comment"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ionosdim_a_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ionosdim_a_record.test", "id", "example.com//host/default/10.88.8.2"),
+					resource.TestCheckResourceAttr("ionosdim_a_record.test", "ttl", "600"),
+					resource.TestCheckResourceAttr("ionosdim_a_record.test", "comment", "other comment"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ionosdim_a_record.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Replace testing
+			{
+				Config: testAccARecordResourceConfig("other-host", 600, "other comment"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ionosdim_a_record.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ionosdim_a_record.test", "id", "example.com//other-host/default/10.88.8.2"),
+					resource.TestCheckResourceAttr("ionosdim_a_record.test", "rr", "other-host A 10.88.8.2"),
+				),
+			},
+			// Drift testing, the deleted record is created again
+			{
+				PreConfig: func() {
+					rr := dim.RR{Type: "A", Name: "other-host", Zone: testAccZone, IP: "10.88.8.2"}
+					if err := c.RRDelete(context.Background(), rr, dim.RRDeleteOptions{}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccARecordResourceConfig("other-host", 600, "other comment"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ionosdim_a_record.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("ionosdim_a_record.test", "rr", "other-host A 10.88.8.2"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccARecordResourceConfig(name string, ttl int, comment string) string {
+	return fmt.Sprintf(`
+resource "ionosdim_ip" "test" {
+  pool = %[1]q
+}
+
+resource "ionosdim_a_record" "test" {
+  name         = %[3]q
+  zone         = %[2]q
+  layer3domain = ionosdim_ip.test.layer3domain
+  ip           = ionosdim_ip.test.ip
+  ttl          = %[4]d
+  comment      = %[5]q
+}
+`, testAccPool, testAccZone, name, ttl, comment)
+}
+
+// testAccCheckNoRecords checks that no records of the type are left in testAccZone
+func testAccCheckNoRecords(c *dim.Client, rrType string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		rrs, err := c.RRList(context.Background(), dim.RRListOptions{Type: rrType, Zone: testAccZone})
+		if err != nil {
+			return err
+		}
+		if len(rrs) > 0 {
+			return fmt.Errorf("%s records left in %s: %+v", rrType, testAccZone, rrs)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,592 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework-nettypes/iptypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &aaaaRecordResource{}
+	_ resource.ResourceWithConfigure   = &aaaaRecordResource{}
+	_ resource.ResourceWithImportState = &aaaaRecordResource{}
+)
+
+func NewAAAARecordResource() resource.Resource {
+	return &aaaaRecordResource{}
+}
+
+type aaaaRecordResource struct {
+	client *dim.Client
+}
+
+type aaaaRecordResourceModel struct {
+	// common identifying RR attributes
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Layer3domain types.String `tfsdk:"layer3domain"`
+	Zone         types.String `tfsdk:"zone"`
+	View         types.String `tfsdk:"view"` // DIM api function rr_create has "views" plural, allowing to specify multiple view at one call. We stick to 1:1 terraform:real_world mapping
+	// AAAA record specific identifying attributes
+	Ip iptypes.IPv6Address `tfsdk:"ip"`
+	// common non-identifying changeable attributes
+	Comment types.String `tfsdk:"comment"`
+	TTL     types.Int64  `tfsdk:"ttl"`
+	// common computed attributes
+	Created    types.String `tfsdk:"created"`
+	CreatedBy  types.String `tfsdk:"created_by"`
+	Modified   types.String `tfsdk:"modified"`
+	ModifiedBy types.String `tfsdk:"modified_by"`
+	RR         types.String `tfsdk:"rr"`
+}
+
+type aaaaRecordID struct {
+	zone         string
+	view         string
+	name         string
+	layer3domain string
+	ip           string
+}
+
+func newAAAARecordIDFromString(s string) (*aaaaRecordID, error) {
+	idParts := strings.SplitN(s, "/", 5)
+	if len(idParts) != 5 {
+		return nil, fmt.Errorf("ID is not in expected format")
+	}
+	// the ID might be specified by user on import
+	ip, err := netip.ParseAddr(idParts[4])
+	if err != nil || !ip.Is6() {
+		return nil, fmt.Errorf("ID is not in expected format, %q is not an IPv6 address", idParts[4])
+	}
+	return &aaaaRecordID{
+		zone:         idParts[0],
+		view:         idParts[1],
+		name:         idParts[2],
+		layer3domain: idParts[3],
+		ip:           ip.String(),
+	}, nil
+}
+
+func newAAAARecordIDFromTfModel(ctx context.Context, m aaaaRecordResourceModel) (*aaaaRecordID, error) {
+	// the ID holds the normalized form of the address,
+	// so that e.g. 2001:db8::1 and 2001:0db8:0::1 result in the same ID
+	ip, diags := m.Ip.ValueIPv6Address()
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s (%s)", diags.Errors()[0].Summary(), diags.Errors()[0].Detail())
+	}
+	if !ip.Is6() {
+		return nil, fmt.Errorf("%q is not an IPv6 address", m.Ip.ValueString())
+	}
+	return &aaaaRecordID{
+		zone:         m.Zone.ValueString(),
+		view:         m.View.ValueString(),
+		name:         m.Name.ValueString(),
+		layer3domain: m.Layer3domain.ValueString(),
+		ip:           ip.String(),
+	}, nil
+}
+
+func (id aaaaRecordID) String() string {
+	// <zone>/<view>/<name>/<layer3domain>/<ip>
+	return fmt.Sprintf("%s/%s/%s/%s/%s", id.zone, id.view, id.name, id.layer3domain, id.ip)
+}
+
+func (id aaaaRecordID) getFqdn() string {
+	if name := id.name; strings.HasSuffix(name, ".") {
+		return name
+	} else {
+		return fmt.Sprintf("%s.%s.", name, id.zone)
+	}
+}
+
+// copyIDToModel set attributes in the model to values
+// from which the ID was composed
+func (r aaaaRecordResource) restoreIDAttributesToModel(ctx context.Context, id aaaaRecordID, rm *aaaaRecordResourceModel) error {
+	// optional args
+	if id.zone != "" {
+		rm.Zone = types.StringValue(id.zone)
+	} else {
+		rm.Zone = types.StringNull()
+	}
+	if id.view != "" {
+		rm.View = types.StringValue(id.view)
+	} else {
+		rm.View = types.StringNull()
+	}
+	if id.layer3domain != "" {
+		rm.Layer3domain = types.StringValue(id.layer3domain)
+	} else {
+		rm.Layer3domain = types.StringNull()
+	}
+	// required args
+	rm.Name = types.StringValue(id.name)
+	// the address in the state is kept intact if it differs from the normalized one
+	// only in representation, see iptypes.IPv6Address semantic equality
+	rm.Ip = iptypes.NewIPv6AddressValue(id.ip)
+	return nil
+}
+
+// readInDimResponse parse the DIM response into the Resource Model,
+// the attributes missing in the response are left intact
+func (r aaaaRecordResource) readInDimResponse(dimResp *dim.RRAttrs, rm *aaaaRecordResourceModel) {
+	if v := dimResp.Created; v != "" {
+		rm.Created = types.StringValue(v)
+	}
+	if v := dimResp.CreatedBy; v != "" {
+		rm.CreatedBy = types.StringValue(v)
+	}
+	if v := dimResp.Modified; v != "" {
+		rm.Modified = types.StringValue(v)
+	}
+	if v := dimResp.ModifiedBy; v != "" {
+		rm.ModifiedBy = types.StringValue(v)
+	}
+	if v := dimResp.RR; v != "" {
+		rm.RR = types.StringValue(v)
+	}
+
+	if v := dimResp.Zone; v != "" {
+		rm.Zone = types.StringValue(v)
+	}
+
+	// read other OPTIONAl attributes
+	if dimResp.TTL != nil {
+		rm.TTL = types.Int64Value(*dimResp.TTL)
+	}
+	if dimResp.Comment != nil {
+		rm.Comment = types.StringValue(*dimResp.Comment)
+	}
+
+	// not confirmed, whether this attributes are returned
+	if dimResp.View != nil {
+		rm.View = types.StringValue(*dimResp.View)
+	}
+}
+
+func (r *aaaaRecordResource) diagErrorSummaryTemplate() string {
+	return "Error in %s AAAA record"
+}
+
+func (r *aaaaRecordResource) diagErrorDetailTemplate() string {
+	return "Unexpected error from %s: %s"
+}
+
+// diagDimError adds the error returned by the DIM function dfunc to diags
+func (r *aaaaRecordResource) diagDimError(diags *diag.Diagnostics, tfAction string, dfunc string, err error) {
+	diags.AddError(
+		fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
+		fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
+	)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *aaaaRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *aaaaRecordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_aaaa_record"
+}
+
+// Schema defines the schema for the resource.
+func (r *aaaaRecordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a AAAA type record in DIM.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "the fqdn of the RR or the relative name if zone was specified",
+			},
+			"zone": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "optional if name is a fqdn",
+			},
+			"view": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"layer3domain": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "value is optional when specifying a RR if there is only one RR with that name, type and value",
+			},
+			"ip": schema.StringAttribute{
+				CustomType: iptypes.IPv6AddressType{},
+				Required:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							// the address changed only in representation, e.g. 2001:db8::1 -> 2001:0db8:0::1
+							equal, diags := iptypes.NewIPv6AddressValue(req.StateValue.ValueString()).StringSemanticEquals(ctx, iptypes.NewIPv6AddressValue(req.PlanValue.ValueString()))
+							resp.Diagnostics.Append(diags...)
+							resp.RequiresReplace = !equal
+						},
+						"If the value of this attribute changes (other than in representation), Terraform will destroy and recreate the resource.",
+						"If the value of this attribute changes (other than in representation), Terraform will destroy and recreate the resource.",
+					),
+				},
+				MarkdownDescription: "IPv6 address, the equivalent representations (e.g. `2001:db8::1` and `2001:0db8:0::1`) are considered equal",
+			},
+
+			"comment": schema.StringAttribute{
+				Optional: true,
+			},
+			"ttl": schema.Int64Attribute{
+				Optional: true,
+			},
+
+			"created": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_by": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified": schema.StringAttribute{
+				Computed: true,
+			},
+			"modified_by": schema.StringAttribute{
+				Computed: true,
+			},
+			"rr": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *aaaaRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Create a new resource.
+	// Retrieve values from data
+	var data aaaaRecordResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := newAAAARecordIDFromTfModel(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Create"),
+			fmt.Sprintf("Unable to compose ID from Resource Model: %s", err.Error()),
+		)
+		return
+	}
+	// rr_create will allocate (set to Static) the specified IP address,
+	// if the latter is not yet allocated.
+	// As the result there will be an IP address not tracked by Terraform.
+	// To avoid this, we will check if the IP address is already allocated,
+	// if it's not, we will reject the plan.
+
+	// check if the IP is already allocated
+	ipAttrs, err := r.client.IPBlockGetAttrs(ctx,
+		id.ip,
+		dim.IPBlockOptions{
+			Host:         true,
+			Layer3domain: id.layer3domain,
+		},
+	)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "ipblock_get_attrs", err)
+		return
+	}
+
+	if ipAttrs.Status != "Static" {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Create"),
+			fmt.Sprintf("IP address %s is not allocated (not marked as Static)", id.ip),
+		)
+		return
+	}
+	//now we know that the IP is allocated
+
+	// required args
+	dimRR := dim.RR{
+		Type: "AAAA",
+		Name: id.name,
+		IP:   id.ip,
+	}
+	// optional args
+	// see https://developer.hashicorp.com/terraform/plugin/framework/handling-data/accessing-values#when-can-a-value-be-unknown-or-null
+
+	// optional
+	if !(data.Layer3domain.IsNull() || data.Layer3domain.IsUnknown()) {
+		dimRR.Layer3domain = id.layer3domain
+	}
+	// optional, computed
+	if !(data.Zone.IsNull() || data.Zone.IsUnknown()) {
+		dimRR.Zone = id.zone
+	}
+	// optional
+	if !(data.View.IsNull() || data.View.IsUnknown()) {
+		dimRR.View = id.view
+	}
+	createOpts := dim.RRCreateOptions{}
+	// optional
+	if !data.Comment.IsNull() {
+		createOpts.Comment = data.Comment.ValueStringPointer()
+	}
+	// optional
+	if !data.TTL.IsNull() {
+		createOpts.TTL = data.TTL.ValueInt64Pointer()
+	}
+
+	err = r.client.RRCreate(ctx, dimRR, createOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "rr_create", err)
+		return
+	}
+	tflog.Info(ctx, "RR has been created", map[string]any{"id": id.String()})
+
+	//rr_get_attrs
+	// rr_get_attrs accepts the subset of the args of rr_create
+	dimRR.Zone = ""
+	dimRR.Name = id.getFqdn() // rr_get_attrs has no "zone" arg, so "name" arg must be fqdn
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "rr_get_attrs", err)
+		return
+	}
+	// not all attributes of a RR are returned by rr_get_attrs,
+	// see the example response in ../../docs/.dim/rr_get_attrs.md
+	r.readInDimResponse(dimResp, &data)
+
+	// now when we know the all values, set the ID
+	data.ID = types.StringValue(id.String())
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *aaaaRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current data
+	var data aaaaRecordResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := newAAAARecordIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Read"),
+			err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("ID parsed %+v", id))
+	r.restoreIDAttributesToModel(ctx, *id, &data)
+
+	// required args
+	dimRR := dim.RR{
+		Type: "AAAA",
+		Name: id.getFqdn(), // rr_get_attrs has no "zone" attr, so "name" must be fqdn with trailing dot
+		IP:   id.ip,
+	}
+	// optional args
+	if id.view != "" {
+		dimRR.View = id.view
+	}
+	if id.layer3domain != "" {
+		dimRR.Layer3domain = id.layer3domain
+	}
+
+	tflog.Info(ctx, "Will read RR", map[string]any{"id": id.String()})
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
+	if err != nil {
+		if _, ok := err.(dim.Error); ok {
+			if err.(dim.Error).Code == 1 {
+				tflog.Debug(ctx, fmt.Sprintf("record not found (has been removed?) %+v", id))
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		r.diagDimError(&resp.Diagnostics, "Read", "rr_get_attrs", err)
+		return
+	}
+	r.readInDimResponse(dimResp, &data)
+	tflog.Info(ctx, "RR has been read", map[string]any{"id": id.String()})
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *aaaaRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// only TTL and comment are updatable
+	// other args result in resource replacement
+
+	// Retrieve values from data
+	var data aaaaRecordResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// required args
+	id, err := newAAAARecordIDFromTfModel(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Update"),
+			fmt.Sprintf("Unable to compose ID from Resource Model: %s", err.Error()),
+		)
+		return
+	}
+	dimRR := dim.RR{
+		Type: "AAAA",
+		Name: id.getFqdn(), // rr_set_attrs has no "zone" attr, so "name" must be fqdn with trailing dot
+		IP:   id.ip,
+	}
+	// optional args
+	if !data.View.IsNull() {
+		dimRR.View = id.view
+	}
+	if !data.Layer3domain.IsNull() {
+		dimRR.Layer3domain = id.layer3domain
+	}
+	//updatable args
+	setOpts := dim.RRSetAttrsOptions{}
+	if !data.TTL.IsNull() {
+		setOpts.TTL = data.TTL.ValueInt64Pointer()
+	}
+	if !data.Comment.IsNull() {
+		setOpts.Comment = data.Comment.ValueStringPointer()
+	}
+
+	tflog.Info(ctx, "Will update RR", map[string]any{"id": id.String()})
+	err = r.client.RRSetAttrs(ctx, dimRR, setOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "rr_set_attrs", err)
+		return
+	}
+	tflog.Info(ctx, "RR has been updated", map[string]any{"id": id.String()})
+
+	// Read the updated attrs
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "rr_get_attrs", err)
+		return
+	}
+	r.readInDimResponse(dimResp, &data)
+
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *aaaaRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from data
+	var data aaaaRecordResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := newAAAARecordIDFromTfModel(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Delete"),
+			err.Error(),
+		)
+		return
+	}
+
+	// required args
+	dimRR := dim.RR{
+		Type: "AAAA",
+		Name: id.name,
+		IP:   id.ip,
+	}
+	// optional args
+	if id.zone != "" {
+		dimRR.Zone = id.zone
+	}
+	if id.view != "" {
+		dimRR.View = id.view
+	}
+	if id.layer3domain != "" {
+		dimRR.Layer3domain = id.layer3domain
+	}
+
+	err = r.client.RRDelete(ctx, dimRR, dim.RRDeleteOptions{References: "warn"})
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Delete", "rr_delete", err)
+		return
+	}
+}
+
+func (r *aaaaRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-nettypes/iptypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAAAARecordIDRoundTrip(t *testing.T) {
+	s := "example.com//host-01/default/2001:db8::1"
+	id, err := newAAAARecordIDFromString(s)
+	if err != nil {
+		t.Fatalf("newAAAARecordIDFromString(%q) error: %s", s, err)
+	}
+	if got := id.String(); got != s {
+		t.Errorf("newAAAARecordIDFromString(%q).String() = %q", s, got)
+	}
+	// the address of an imported ID is normalized
+	s = "example.com//host-01//2001:0DB8:0::1"
+	id, err = newAAAARecordIDFromString(s)
+	if err != nil {
+		t.Fatalf("newAAAARecordIDFromString(%q) error: %s", s, err)
+	}
+	if got, wants := id.String(), "example.com//host-01//2001:db8::1"; got != wants {
+		t.Errorf("newAAAARecordIDFromString(%q).String() = %q ; wants = %q", s, got, wants)
+	}
+	for _, s := range []string{"example.com//host-01//10.0.0.1", "example.com//host-01//not-an-ip", "example.com//host-01/2001:db8::1"} {
+		if _, err := newAAAARecordIDFromString(s); err == nil {
+			t.Errorf("newAAAARecordIDFromString(%q) accepted an invalid ID", s)
+		}
+	}
+}
+
+func TestAAAARecordIDFromTfModel(t *testing.T) {
+	ctx := context.Background()
+	for _, ip := range []string{"2001:db8::1", "2001:0db8:0::1", "2001:DB8:0:0:0:0:0:1"} {
+		m := aaaaRecordResourceModel{
+			Name: types.StringValue("host-01"),
+			Zone: types.StringValue("example.com"),
+			Ip:   iptypes.NewIPv6AddressValue(ip),
+		}
+		id, err := newAAAARecordIDFromTfModel(ctx, m)
+		if err != nil {
+			t.Fatalf("newAAAARecordIDFromTfModel(%q) error: %s", ip, err)
+		}
+		if got, wants := id.String(), "example.com//host-01//2001:db8::1"; got != wants {
+			t.Errorf("newAAAARecordIDFromTfModel(%q).String() = %q ; wants = %q", ip, got, wants)
+		}
+		// the address in the state is kept by the framework, as it is semantically equal
+		equal, diags := m.Ip.StringSemanticEquals(ctx, iptypes.NewIPv6AddressValue(id.ip))
+		if diags.HasError() || !equal {
+			t.Errorf("%q is not semantically equal to %q", ip, id.ip)
+		}
+	}
+	m := aaaaRecordResourceModel{Ip: iptypes.NewIPv6AddressValue("10.0.0.1")}
+	if _, err := newAAAARecordIDFromTfModel(ctx, m); err == nil {
+		t.Errorf("newAAAARecordIDFromTfModel() accepted an IPv4 address")
+	}
+}
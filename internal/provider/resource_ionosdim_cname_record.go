@@ -114,39 +114,40 @@ func (r cnameRecordResource) restoreIDAttributesToModel(ctx context.Context, id
 	return nil
 }
 
-// readInDimResponse parse the DIM response into the Resource Model
-func (r cnameRecordResource) readInDimResponse(dimResp map[string]any, rm *cnameRecordResourceModel) {
-	if v, ok := dimResp["created"]; ok {
-		rm.Created = types.StringValue(v.(string))
+// readInDimResponse parse the DIM response into the Resource Model,
+// the attributes missing in the response are left intact
+func (r cnameRecordResource) readInDimResponse(dimResp *dim.RRAttrs, rm *cnameRecordResourceModel) {
+	if v := dimResp.Created; v != "" {
+		rm.Created = types.StringValue(v)
 	}
-	if v, ok := dimResp["created_by"]; ok {
-		rm.CreatedBy = types.StringValue(v.(string))
+	if v := dimResp.CreatedBy; v != "" {
+		rm.CreatedBy = types.StringValue(v)
 	}
-	if v, ok := dimResp["modified"]; ok {
-		rm.Modified = types.StringValue(v.(string))
+	if v := dimResp.Modified; v != "" {
+		rm.Modified = types.StringValue(v)
 	}
-	if v, ok := dimResp["modified_by"]; ok {
-		rm.ModifiedBy = types.StringValue(v.(string))
+	if v := dimResp.ModifiedBy; v != "" {
+		rm.ModifiedBy = types.StringValue(v)
 	}
-	if v, ok := dimResp["rr"]; ok {
-		rm.RR = types.StringValue(v.(string))
+	if v := dimResp.RR; v != "" {
+		rm.RR = types.StringValue(v)
 	}
 
-	if v, ok := dimResp["zone"]; ok {
-		rm.Zone = types.StringValue(v.(string))
+	if v := dimResp.Zone; v != "" {
+		rm.Zone = types.StringValue(v)
 	}
 
 	// read other OPTIONAl attributes
-	if v, ok := dimResp["TTL"]; ok {
-		rm.TTL = types.Int64Value(v.(int64))
+	if dimResp.TTL != nil {
+		rm.TTL = types.Int64Value(*dimResp.TTL)
 	}
-	if v, ok := dimResp["comment"]; ok {
-		rm.Comment = types.StringValue(v.(string))
+	if dimResp.Comment != nil {
+		rm.Comment = types.StringValue(*dimResp.Comment)
 	}
 
 	// not confirmed, whether this attributes are returned
-	if v, ok := dimResp["view"]; ok {
-		rm.View = types.StringValue(v.(string))
+	if dimResp.View != nil {
+		rm.View = types.StringValue(*dimResp.View)
 	}
 }
 
@@ -158,21 +159,12 @@ func (r *cnameRecordResource) diagErrorDetailTemplate() string {
 	return "Unexpected error from %s: %s"
 }
 
-// dimRawCall is a helper function to call DIM API and handle errors and logging
-func (r *cnameRecordResource) dimRawCall(ctx context.Context, tfAction string, dfunc string, dargs []any, diags *diag.Diagnostics) (any, error) {
-	tflog.Debug(ctx, fmt.Sprintf("%s/%s call", tfAction, dfunc), map[string]any{"func": dfunc, "args": dargs})
-	dimResp, err := r.client.RawCallWithContext(ctx, dfunc, dargs)
-	if err != nil {
-		if diags != nil {
-			diags.AddError(
-				fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
-				fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
-			)
-		}
-		return nil, err
-	}
-	tflog.Debug(ctx, fmt.Sprintf("%s/%s response", tfAction, dfunc), map[string]any{"dimResponse": dimResp})
-	return dimResp, nil
+// diagDimError adds the error returned by the DIM function dfunc to diags
+func (r *cnameRecordResource) diagDimError(diags *diag.Diagnostics, tfAction string, dfunc string, err error) {
+	diags.AddError(
+		fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
+		fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
+	)
 }
 
 // Configure adds the provider configured client to the resource.
@@ -294,61 +286,49 @@ func (r *cnameRecordResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	dim_req_args := map[string]any{
-		"type":  "CNAME",
-		"name":  id.name,
-		"cname": id.cname,
+	dimRR := dim.RR{
+		Type:  "CNAME",
+		Name:  id.name,
+		CNAME: id.cname,
 	}
 	// optional args
 	// see https://developer.hashicorp.com/terraform/plugin/framework/handling-data/accessing-values#when-can-a-value-be-unknown-or-null
 
 	// optional, computed
 	if !(data.Zone.IsNull() || data.Zone.IsUnknown()) {
-		dim_req_args["zone"] = id.zone
+		dimRR.Zone = id.zone
 	}
 	// optional
 	if !(data.View.IsNull() || data.View.IsUnknown()) {
-		dim_req_args["view"] = id.view
+		dimRR.View = id.view
 	}
+	createOpts := dim.RRCreateOptions{}
 	// optional
 	if !(data.Comment.IsNull() || data.Comment.IsUnknown()) {
-		dim_req_args["comment"] = data.Comment.ValueString()
+		createOpts.Comment = data.Comment.ValueStringPointer()
 	}
 	// optional
 	if !(data.TTL.IsNull() || data.TTL.IsUnknown()) {
-		dim_req_args["ttl"] = data.TTL.ValueInt64()
+		createOpts.TTL = data.TTL.ValueInt64Pointer()
 	}
 
-	_, err = r.dimRawCall(ctx, "Create",
-		"rr_create",
-		[]any{
-			dim_req_args,
-		},
-		&resp.Diagnostics,
-	)
+	err = r.client.RRCreate(ctx, dimRR, createOpts)
 	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "rr_create", err)
 		return
 	}
-	tflog.Info(ctx, "RR has been created", dim_req_args)
+	tflog.Info(ctx, "RR has been created", map[string]any{"id": id.String(), "args": []any{dimRR, createOpts}})
 
 	//rr_get_attrs
 	// rr_get_attrs accepts the subset of the args of rr_create
-	// so to reuse request args, we will remove some of them
-	delete(dim_req_args, "ttl")
-	delete(dim_req_args, "comment")
-	delete(dim_req_args, "zone")
-	dim_req_args["name"] = id.getFqdn()
-	dimResp, err := r.dimRawCall(ctx, "Create",
-		"rr_get_attrs",
-		[]any{
-			dim_req_args,
-		},
-		&resp.Diagnostics,
-	)
+	dimRR.Zone = ""
+	dimRR.Name = id.getFqdn() // rr_get_attrs has no "zone" arg, so "name" arg must be fqdn
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
 	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "rr_get_attrs", err)
 		return
 	}
-	r.readInDimResponse(dimResp.(map[string]any), &data)
+	r.readInDimResponse(dimResp, &data)
 
 	// now when we know the all values, set the ID
 	data.ID = types.StringValue(id.String())
@@ -383,24 +363,20 @@ func (r *cnameRecordResource) Read(ctx context.Context, req resource.ReadRequest
 	r.restoreIDAttributesToModel(ctx, *id, &data)
 
 	// required args
-	dim_req_args := map[string]any{
-		"type":  "CNAME",
-		"name":  id.getFqdn(), // rr_set_attrs has no "zone" attr, so "name" must be fqdn with trailing dot
-		"cname": id.cname,
+	dimRR := dim.RR{
+		Type:  "CNAME",
+		Name:  id.getFqdn(), // rr_get_attrs accepts no "zone" arg, so "name" must be fqdn with trailing dot
+		CNAME: id.cname,
 	}
 	// optional args
+	// we do not check if !data.View.IsNull(), because the state might be null
+	// , e.g, after import
 	if id.view != "" {
-		dim_req_args["view"] = id.view
+		dimRR.View = id.view
 	}
 
-	tflog.Info(ctx, "Will read RR", dim_req_args)
-	dimResp, err := r.dimRawCall(ctx, "Read",
-		"rr_get_attrs",
-		[]any{
-			dim_req_args,
-		},
-		nil,
-	)
+	tflog.Info(ctx, "Will read RR", map[string]any{"id": id.String(), "args": []any{dimRR}})
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
 	if err != nil {
 		if _, ok := err.(dim.Error); ok {
 			if err.(dim.Error).Code == 1 {
@@ -409,14 +385,11 @@ func (r *cnameRecordResource) Read(ctx context.Context, req resource.ReadRequest
 				return
 			}
 		}
-		resp.Diagnostics.AddError(
-			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Read"),
-			fmt.Sprintf(r.diagErrorDetailTemplate(), "rr_get_attrs", err.Error()),
-		)
+		r.diagDimError(&resp.Diagnostics, "Read", "rr_get_attrs", err)
 		return
 	}
-	r.readInDimResponse(dimResp.(map[string]any), &data)
-	tflog.Info(ctx, "RR has been read", dim_req_args)
+	r.readInDimResponse(dimResp, &data)
+	tflog.Info(ctx, "RR has been read", map[string]any{"id": id.String(), "args": []any{dimRR}})
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &data)
@@ -448,36 +421,39 @@ func (r *cnameRecordResource) Update(ctx context.Context, req resource.UpdateReq
 		)
 		return
 	}
-	dim_req_args := map[string]any{
-		"type":  "CNAME",
-		"name":  id.getFqdn(), // rr_set_attrs has no "zone" attr, so "name" must be fqdn with trailing dot
-		"cname": id.cname,
+	dimRR := dim.RR{
+		Type:  "CNAME",
+		Name:  id.getFqdn(), // rr_set_attrs has no "zone" attr, so "name" must be fqdn with trailing dot
+		CNAME: id.cname,
 	}
 	// optional args
 	if !data.View.IsNull() {
-		dim_req_args["view"] = id.view
+		dimRR.View = id.view
 	}
 	//updatable args
+	setOpts := dim.RRSetAttrsOptions{}
 	if !data.TTL.IsNull() {
-		dim_req_args["ttl"] = data.TTL.ValueInt64()
+		setOpts.TTL = data.TTL.ValueInt64Pointer()
 	}
 	if !data.Comment.IsNull() {
-		dim_req_args["comment"] = data.Comment.ValueString()
+		setOpts.Comment = data.Comment.ValueStringPointer()
 	}
 
-	tflog.Info(ctx, "Will update RR", dim_req_args)
-	dimResp, _ := r.dimRawCall(ctx, "Update",
-		"rr_set_attrs",
-		[]any{
-			dim_req_args,
-		},
-		&resp.Diagnostics,
-	)
-	if resp.Diagnostics.HasError() {
+	tflog.Info(ctx, "Will update RR", map[string]any{"id": id.String(), "args": []any{dimRR, setOpts}})
+	err = r.client.RRSetAttrs(ctx, dimRR, setOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "rr_set_attrs", err)
+		return
+	}
+	tflog.Info(ctx, "RR has been updated", map[string]any{"id": id.String(), "args": []any{dimRR, setOpts}})
+
+	// Read the updated attrs
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "rr_get_attrs", err)
 		return
 	}
-	r.readInDimResponse(dimResp.(map[string]any), &data)
-	tflog.Info(ctx, "RR has been updated", dim_req_args)
+	r.readInDimResponse(dimResp, &data)
 
 	diags = resp.State.Set(ctx, data)
 	resp.Diagnostics.Append(diags...)
@@ -500,36 +476,28 @@ func (r *cnameRecordResource) Delete(ctx context.Context, req resource.DeleteReq
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Delete"),
-			err.Error(),
+			fmt.Sprintf("Unable to compose ID from Resource Model: %s", err.Error()),
 		)
 		return
 	}
 
 	// required args
-	dim_req_args := map[string]any{
-		"type":  "CNAME",
-		"name":  id.name,
-		"cname": id.cname,
+	dimRR := dim.RR{
+		Type:  "CNAME",
+		Name:  id.name,
+		CNAME: id.cname,
 	}
 	// optional args
 	if id.zone != "" {
-		dim_req_args["zone"] = id.zone
+		dimRR.Zone = id.zone
 	}
 	if id.view != "" {
-		dim_req_args["view"] = id.view
+		dimRR.View = id.view
 	}
-	// delete specific args
-	dim_req_args["references"] = "warn"
 
-	//	_, err := i.client.RawCall("rr_delete", []any{dim_req_args})
-	r.dimRawCall(ctx, "Delete",
-		"rr_delete",
-		[]any{
-			dim_req_args,
-		},
-		&resp.Diagnostics,
-	)
-	if resp.Diagnostics.HasError() {
+	err = r.client.RRDelete(ctx, dimRR, dim.RRDeleteOptions{References: "warn"})
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Delete", "rr_delete", err)
 		return
 	}
 }
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccCNAMERecordResource(t *testing.T) {
+	s := testAccDIM(t)
+	c := testAccDIMClient(t, s)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckNoRecords(c, "CNAME"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCNAMERecordResourceConfig("host.example.com.", 300, "some comment"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ionosdim_cname_record.test", "id", "example.com//www/host.example.com."),
+					resource.TestCheckResourceAttr("ionosdim_cname_record.test", "ttl", "300"),
+					resource.TestCheckResourceAttr("ionosdim_cname_record.test", "comment", "some comment"),
+					resource.TestCheckResourceAttr("ionosdim_cname_record.test", "rr", "www CNAME host.example.com."),
+				),
+			},
+			// Update in place testing
+			{
+				Config: testAccCNAMERecordResourceConfig("host.example.com.", 600, "other comment"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ionosdim_cname_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ionosdim_cname_record.test", "ttl", "600"),
+					resource.TestCheckResourceAttr("ionosdim_cname_record.test", "comment", "other comment"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ionosdim_cname_record.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Replace testing
+			{
+				Config: testAccCNAMERecordResourceConfig("other-host.example.com.", 600, "other comment"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ionosdim_cname_record.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ionosdim_cname_record.test", "id", "example.com//www/other-host.example.com."),
+					resource.TestCheckResourceAttr("ionosdim_cname_record.test", "rr", "www CNAME other-host.example.com."),
+				),
+			},
+			// Drift testing, the deleted record is created again
+			{
+				PreConfig: func() {
+					rr := dim.RR{Type: "CNAME", Name: "www", Zone: testAccZone, CNAME: "other-host.example.com."}
+					if err := c.RRDelete(context.Background(), rr, dim.RRDeleteOptions{}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccCNAMERecordResourceConfig("other-host.example.com.", 600, "other comment"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ionosdim_cname_record.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("ionosdim_cname_record.test", "rr", "www CNAME other-host.example.com."),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccCNAMERecordResourceConfig(cname string, ttl int, comment string) string {
+	return fmt.Sprintf(`
+resource "ionosdim_cname_record" "test" {
+  name    = "www"
+  zone    = %[1]q
+  cname   = %[2]q
+  ttl     = %[3]d
+  comment = %[4]q
+}
+`, testAccZone, cname, ttl, comment)
+}
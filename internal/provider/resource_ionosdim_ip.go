@@ -7,12 +7,15 @@ import (
 
 	"terraform-provider-ionosdim/pkg/dim"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -49,7 +52,8 @@ type ipResourceModel struct {
 	Status      types.String `tfsdk:"status"`
 	Subnet      types.String `tfsdk:"subnet"`
 
-	Comment types.String `tfsdk:"comment"`
+	Comment    types.String `tfsdk:"comment"`
+	Attributes types.Map    `tfsdk:"attributes"`
 }
 
 type ipID struct {
@@ -73,46 +77,68 @@ func (rm ipResourceModel) parseID() (ipID, error) {
 	}, nil
 }
 
-func (rm *ipResourceModel) readInDimResponse(dimResp map[string]any) {
-	if v, ok := dimResp["ip"]; ok {
-		rm.Ip = types.StringValue(v.(string))
+// dimAttributes returns the DIM attributes of the ip
+// i.e. the user defined attributes and the comment
+func (rm ipResourceModel) dimAttributes(ctx context.Context) (map[string]string, diag.Diagnostics) {
+	attrs, diags := attributesFromMap(ctx, rm.Attributes)
+	if diags.HasError() {
+		return nil, diags
 	}
-	if v, ok := dimResp["layer3domain"]; ok {
-		rm.Layer3domain = types.StringValue(v.(string))
+	if !(rm.Comment.IsNull() || rm.Comment.IsUnknown()) {
+		if attrs == nil {
+			attrs = map[string]string{}
+		}
+		attrs["comment"] = rm.Comment.ValueString()
 	}
+	return attrs, diags
+}
 
-	if v, ok := dimResp["created"]; ok {
-		rm.Created = types.StringValue(v.(string))
+// readInDimResponse parse the DIM response into the Resource Model,
+// the attributes missing in the response are left intact
+func (rm *ipResourceModel) readInDimResponse(ctx context.Context, dimResp *dim.IPBlockAttrs) diag.Diagnostics {
+	if v := dimResp.IP; v != "" {
+		rm.Ip = types.StringValue(v)
 	}
-	if v, ok := dimResp["modified"]; ok {
-		rm.Modified = types.StringValue(v.(string))
+	if v := dimResp.Layer3domain; v != "" {
+		rm.Layer3domain = types.StringValue(v)
+	}
+
+	if v := dimResp.Created; v != "" {
+		rm.Created = types.StringValue(v)
 	}
-	if v, ok := dimResp["modified_by"]; ok {
-		rm.ModifiedBy = types.StringValue(v.(string))
+	if v := dimResp.Modified; v != "" {
+		rm.Modified = types.StringValue(v)
+	}
+	if v := dimResp.ModifiedBy; v != "" {
+		rm.ModifiedBy = types.StringValue(v)
 	}
 
-	if v, ok := dimResp["gateway"]; ok {
-		rm.Gateway = types.StringValue(v.(string))
+	if v := dimResp.Gateway; v != "" {
+		rm.Gateway = types.StringValue(v)
 	}
-	if v, ok := dimResp["mask"]; ok {
-		rm.Mask = types.StringValue(v.(string))
+	if v := dimResp.Mask; v != "" {
+		rm.Mask = types.StringValue(v)
 	}
-	if v, ok := dimResp["pool"]; ok {
-		rm.Pool = types.StringValue(v.(string))
+	if v := dimResp.Pool; v != "" {
+		rm.Pool = types.StringValue(v)
 	}
-	if v, ok := dimResp["reverse_zone"]; ok {
-		rm.ReverseZone = types.StringValue(v.(string))
+	if v := dimResp.ReverseZone; v != "" {
+		rm.ReverseZone = types.StringValue(v)
 	}
-	if v, ok := dimResp["subnet"]; ok {
-		rm.Subnet = types.StringValue(v.(string))
+	if v := dimResp.Subnet; v != "" {
+		rm.Subnet = types.StringValue(v)
 	}
-	if v, ok := dimResp["status"]; ok {
-		rm.Status = types.StringValue(v.(string))
+	if v := dimResp.Status; v != "" {
+		rm.Status = types.StringValue(v)
 	}
 
-	if v, ok := dimResp["comment"]; ok {
-		rm.Comment = types.StringValue(v.(string))
+	if dimResp.Comment != nil {
+		rm.Comment = types.StringValue(*dimResp.Comment)
 	}
+
+	var diags diag.Diagnostics
+	rm.Attributes, diags = refreshAttributes(ctx, rm.Attributes, dimResp.Attributes)
+	return diags
 }
 
 func (r *ipResource) diagErrorSummaryTemplate() string {
@@ -127,18 +153,12 @@ func (r *ipResource) diagWarningSummaryTemplate() string {
 	return "Warning in %s ip"
 }
 
-func (r *ipResource) dimRawCall(ctx context.Context, tfAction string, dfunc string, dargs []any, diags *diag.Diagnostics) (any, error) {
-	tflog.Debug(ctx, fmt.Sprintf("ip/%s dim-call", tfAction), map[string]any{"func": dfunc, "args": dargs})
-	dimResp, err := r.client.RawCallWithContext(ctx, dfunc, dargs)
-	if err != nil {
-		diags.AddError(
-			fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
-			fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
-		)
-		return nil, err
-	}
-	tflog.Debug(ctx, fmt.Sprintf("ip/%s dim-response", tfAction), map[string]any{"dimResponse": dimResp})
-	return dimResp, nil
+// diagDimError adds the error returned by the DIM function dfunc to diags
+func (r *ipResource) diagDimError(diags *diag.Diagnostics, tfAction string, dfunc string, err error) {
+	diags.AddError(
+		fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
+		fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
+	)
 }
 
 // Configure adds the provider configured client to the resource.
@@ -201,6 +221,17 @@ func (r *ipResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 				Optional:            true,
 				MarkdownDescription: "The comment to the allocated IP address",
 			},
+			"attributes": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.NoneOf(ipBlockReservedAttributes...)),
+				},
+				MarkdownDescription: "The user defined attributes of the allocated IP address, e.g. `owner` or `ticket`. " +
+					"The DIM fields, e.g. `comment` or `status`, can not be used as attributes. " +
+					"Only the attributes set here are managed, the other attributes of the address in DIM are ignored. " +
+					"The attributes are not read on import, so the first apply after an import sets them again.",
+			},
 
 			"layer3domain": schema.StringAttribute{
 				Computed: true,
@@ -272,24 +303,26 @@ func (r *ipResource) Create(ctx context.Context, req resource.CreateRequest, res
 		return
 	}
 
-	dim_req_named_args := map[string]any{}
-
-	if !data.Comment.IsNull() {
-		dim_req_named_args["attributes"] = map[string]any{"comment": data.Comment.ValueString()}
+	attributes, diags := data.dimAttributes(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	var dimResp any
+	var dimResp *dim.IPBlockAttrs
+	var err error
 
 	if data.Ip.IsUnknown() {
 		// will get a free IP from the pool
-		dimResp, _ = r.dimRawCall(ctx, "Create",
-			"ippool_get_ip",
-			[]any{
-				data.Pool.ValueString(),
-				dim_req_named_args,
+		dimResp, err = r.client.IPPoolGetIP(ctx,
+			data.Pool.ValueString(),
+			dim.IPPoolGetIPOptions{
+				Attributes: attributes,
 			},
-			&resp.Diagnostics,
 		)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Create", "ippool_get_ip", err)
+		}
 	} else {
 		// will reserve the specific IP checking:
 		// 1) that it's within the pool
@@ -298,26 +331,34 @@ func (r *ipResource) Create(ctx context.Context, req resource.CreateRequest, res
 		//    specifying the status returns error "ip_mark error (19): Unknown options: status"
 		//    so it's not set here. But ip_mark refuses to make Static the addresss
 		//    which is alrady static, so we are safe.
-		dim_req_named_args["pool"] = data.Pool.ValueString()
-		dim_req_named_args["host"] = true
-		dimResp, _ = r.dimRawCall(ctx, "Create",
-			"ip_mark",
-			[]any{
-				data.Ip.ValueString(),
-				dim_req_named_args,
+		dimResp, err = r.client.IPMark(ctx,
+			data.Ip.ValueString(),
+			dim.IPMarkOptions{
+				IPBlockOptions: dim.IPBlockOptions{
+					Pool: data.Pool.ValueString(),
+					Host: true,
+				},
+				Attributes: attributes,
 			},
-			&resp.Diagnostics,
 		)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Create", "ip_mark", err)
+		}
 	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	data.readInDimResponse(dimResp.(map[string]any))
+	resp.Diagnostics.Append(data.readInDimResponse(ctx, dimResp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	tflog.Info(ctx, "IP has been made static", map[string]any{
 		"layer3domain": data.Layer3domain.ValueString(),
 		"ip":           data.Ip.ValueString(),
+		"pool":         data.Pool.ValueString(),
+		"attributes":   attributes,
 	})
 	// now when we know the all values, set the ID
 	data.ID = types.StringValue(data.composeID())
@@ -351,23 +392,21 @@ func (r *ipResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 	}
 	tflog.Debug(ctx, fmt.Sprintf("ID parsed %+v", id))
 
-	dim_req_args := map[string]any{
-		"host":         true,
-		"layer3domain": id.layer3domain,
-	}
-
-	dimResp, _ := r.dimRawCall(ctx, "Read",
-		"ipblock_get_attrs",
-		[]any{
-			id.ip,
-			dim_req_args,
+	dimResp, err := r.client.IPBlockGetAttrs(ctx,
+		id.ip,
+		dim.IPBlockOptions{
+			Host:         true,
+			Layer3domain: id.layer3domain,
 		},
-		&resp.Diagnostics,
 	)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Read", "ipblock_get_attrs", err)
+		return
+	}
+	resp.Diagnostics.Append(data.readInDimResponse(ctx, dimResp)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	data.readInDimResponse(dimResp.(map[string]any))
 
 	if data.ID.ValueString() != data.composeID() {
 		resp.Diagnostics.AddError(
@@ -409,56 +448,62 @@ func (r *ipResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *ipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 
-	var data ipResourceModel
-	diags := req.Plan.Get(ctx, &data)
-	resp.Diagnostics.Append(diags...)
+	var data, state ipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	id, _ := data.parseID() // well we know it's valid, so no need to check the error
 
-	opts := map[string]any{
-		"host":         true,
-		"layer3domain": id.layer3domain,
-		"pool":         data.Pool.ValueString(),
+	opts := dim.IPBlockOptions{
+		Host:         true,
+		Layer3domain: id.layer3domain,
+		Pool:         data.Pool.ValueString(),
 	}
 
-	_, _ = r.dimRawCall(ctx, "Update",
-		"ipblock_set_attrs",
-		[]any{
-			id.ip,
-			// attributes
-			map[string]any{
-				"comment": data.Comment.ValueString(),
-			},
-			// options
-			opts,
-		}, &resp.Diagnostics)
-
+	newAttrs, diags := data.dimAttributes(ctx)
+	resp.Diagnostics.Append(diags...)
+	oldAttrs, diags := state.dimAttributes(ctx)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	setAttrs, delAttrs := diffAttributes(newAttrs, oldAttrs)
+	if len(setAttrs) > 0 {
+		err := r.client.IPBlockSetAttrs(ctx, id.ip, setAttrs, opts)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Update", "ipblock_set_attrs", err)
+			return
+		}
+	}
+	if len(delAttrs) > 0 {
+		err := r.client.IPBlockDeleteAttrs(ctx, id.ip, delAttrs, opts)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Update", "ipblock_delete_attrs", err)
+			return
+		}
+	}
 
 	tflog.Info(ctx, "IP has been updated", map[string]any{
-		"layer3domain": id.layer3domain,
-		"ip":           id.ip,
+		"layer3domain":       id.layer3domain,
+		"ip":                 id.ip,
+		"set_attributes":     setAttrs,
+		"deleted_attributes": delAttrs,
 	})
 
 	// Read the updated attrs
 
-	dimResp, _ := r.dimRawCall(ctx, "Update",
-		"ipblock_get_attrs",
-		[]any{
-			id.ip,
-			opts,
-		},
-		&resp.Diagnostics,
-	)
+	dimResp, err := r.client.IPBlockGetAttrs(ctx, id.ip, opts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "ipblock_get_attrs", err)
+		return
+	}
+	resp.Diagnostics.Append(data.readInDimResponse(ctx, dimResp)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	data.readInDimResponse(dimResp.(map[string]any))
 
 	diags = resp.State.Set(ctx, data)
 	resp.Diagnostics.Append(diags...)
@@ -487,25 +532,20 @@ func (r *ipResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 		return
 	}
 
-	dim_req_args := map[string]any{
-		"layer3domain": id.layer3domain,
-		"host":         true,
-		"pool":         data.Pool.ValueString(),
-	}
-
-	dimResp, _ := r.dimRawCall(ctx, "Delete",
-		"ip_free",
-		[]any{
-			id.ip,
-			dim_req_args,
+	dimResp, err := r.client.IPFree(ctx,
+		id.ip,
+		dim.IPBlockOptions{
+			Layer3domain: id.layer3domain,
+			Host:         true,
+			Pool:         data.Pool.ValueString(),
 		},
-		&resp.Diagnostics,
 	)
-	if resp.Diagnostics.HasError() {
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Delete", "ip_free", err)
 		return
 	}
 
-	if _res := int(dimResp.(float64)); _res != 1 {
+	if _res := dimResp; _res != 1 {
 		if _res == -1 {
 			resp.Diagnostics.AddError(
 				fmt.Sprintf(r.diagErrorSummaryTemplate(), "Delete"),
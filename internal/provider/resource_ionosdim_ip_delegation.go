@@ -0,0 +1,536 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &ipDelegationResource{}
+	_ resource.ResourceWithConfigure   = &ipDelegationResource{}
+	_ resource.ResourceWithImportState = &ipDelegationResource{}
+)
+
+func NewIPDelegationResource() resource.Resource {
+	return &ipDelegationResource{}
+}
+
+type ipDelegationResource struct {
+	client *dim.Client
+}
+
+type ipDelegationResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Pool         types.String `tfsdk:"pool"`
+	PrefixLength types.Int64  `tfsdk:"prefix_length"`
+	Block        types.String `tfsdk:"block"`
+	Layer3domain types.String `tfsdk:"layer3domain"`
+
+	Comment types.String `tfsdk:"comment"`
+
+	FirstAddress types.String `tfsdk:"first_address"`
+	LastAddress  types.String `tfsdk:"last_address"`
+	Gateway      types.String `tfsdk:"gateway"`
+	Mask         types.String `tfsdk:"mask"`
+	Subnet       types.String `tfsdk:"subnet"`
+	Status       types.String `tfsdk:"status"`
+	Created      types.String `tfsdk:"created"` // no created_by field!
+	Modified     types.String `tfsdk:"modified"`
+	ModifiedBy   types.String `tfsdk:"modified_by"`
+}
+
+type ipDelegationID struct {
+	layer3domain string
+	block        string
+}
+
+func (rm ipDelegationResourceModel) composeID() string {
+	// <layer3domain>/<block>, e.g. default/10.0.0.0/26
+	return rm.Layer3domain.ValueString() + "/" + rm.Block.ValueString()
+}
+
+func (rm ipDelegationResourceModel) parseID() (ipDelegationID, error) {
+	idParts := strings.SplitN(rm.ID.ValueString(), "/", 2)
+	if len(idParts) != 2 {
+		return ipDelegationID{}, fmt.Errorf("ID is not in expected format")
+	}
+	return ipDelegationID{
+		layer3domain: idParts[0],
+		block:        idParts[1],
+	}, nil
+}
+
+// prefixRange returns the first and the last address of the prefix
+func prefixRange(p netip.Prefix) (netip.Addr, netip.Addr) {
+	p = p.Masked()
+	first := p.Addr()
+	last := first.AsSlice()
+	for i := p.Bits(); i < len(last)*8; i++ {
+		last[i/8] |= 0x80 >> (i % 8)
+	}
+	lastAddr, _ := netip.AddrFromSlice(last)
+	return first, lastAddr
+}
+
+// readInDimResponse parse the DIM response into the Resource Model,
+// the attributes missing in the response are left intact
+func (rm *ipDelegationResourceModel) readInDimResponse(dimResp *dim.IPBlockAttrs) {
+	if v := dimResp.IP; v != "" {
+		// keep the configured spelling of the block
+		if !samePrefix(rm.Block.ValueString(), v) {
+			rm.Block = types.StringValue(v)
+		}
+		if p, err := netip.ParsePrefix(v); err == nil {
+			first, last := prefixRange(p)
+			rm.FirstAddress = types.StringValue(first.String())
+			rm.LastAddress = types.StringValue(last.String())
+			rm.PrefixLength = types.Int64Value(int64(p.Bits()))
+		}
+	}
+	if v := dimResp.Layer3domain; v != "" {
+		rm.Layer3domain = types.StringValue(v)
+	}
+	if v := dimResp.Pool; v != "" {
+		rm.Pool = types.StringValue(v)
+	}
+
+	if v := dimResp.Created; v != "" {
+		rm.Created = types.StringValue(v)
+	}
+	if v := dimResp.Modified; v != "" {
+		rm.Modified = types.StringValue(v)
+	}
+	if v := dimResp.ModifiedBy; v != "" {
+		rm.ModifiedBy = types.StringValue(v)
+	}
+	if v := dimResp.Gateway; v != "" {
+		rm.Gateway = types.StringValue(v)
+	}
+	if v := dimResp.Mask; v != "" {
+		rm.Mask = types.StringValue(v)
+	}
+	if v := dimResp.Subnet; v != "" {
+		rm.Subnet = types.StringValue(v)
+	}
+	if v := dimResp.Status; v != "" {
+		rm.Status = types.StringValue(v)
+	}
+
+	if dimResp.Comment != nil {
+		rm.Comment = types.StringValue(*dimResp.Comment)
+	}
+}
+
+func (r *ipDelegationResource) diagErrorSummaryTemplate() string {
+	return "Error in %s ip delegation"
+}
+
+func (r *ipDelegationResource) diagErrorDetailTemplate() string {
+	return "Unexpected error from %s: %s"
+}
+
+// diagDimError adds the error returned by the DIM function dfunc to diags
+func (r *ipDelegationResource) diagDimError(diags *diag.Diagnostics, tfAction string, dfunc string, err error) {
+	diags.AddError(
+		fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
+		fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
+	)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ipDelegationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *ipDelegationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip_delegation"
+}
+
+// Schema defines the schema for the resource.
+func (r *ipDelegationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Allocates a block of ip addresses (`status` = `Delegation`) from the pool.\n" +
+			" - If `prefix_length` is specified, the next free block of this size is allocated;\n" +
+			" - If `block` is specified, this block is allocated, it must be free and within the `pool`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"pool": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "The pool where the block is allocated.",
+			},
+			"prefix_length": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 128),
+					int64validator.ExactlyOneOf(path.MatchRoot("block")),
+				},
+				MarkdownDescription: "The prefix length of the block to allocate, e.g. `26`. Conflicts with `block`.",
+			},
+			"block": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					prefixValidator{},
+				},
+				MarkdownDescription: "The allocated block in CIDR notation, without host bits. If specified, this block will be allocated. Conflicts with `prefix_length`.",
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The comment to the allocated block",
+			},
+
+			"layer3domain": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The layer 3 domain where the block is allocated.",
+			},
+			"first_address": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The first address of the block.",
+			},
+			"last_address": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The last address of the block.",
+			},
+			"gateway": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The gateway of the subnet the block belongs to.",
+			},
+			"mask": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"subnet": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified": schema.StringAttribute{
+				Computed: true,
+			},
+			"modified_by": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ipDelegationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from data
+	var data ipDelegationResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var attributes map[string]string
+
+	if !data.Comment.IsNull() {
+		attributes = map[string]string{"comment": data.Comment.ValueString()}
+	}
+
+	pool := data.Pool.ValueString()
+	var block, layer3domain string
+
+	if data.Block.IsUnknown() {
+		// will get a free block from the pool
+		dimResp, err := r.client.IPPoolGetDelegation(ctx,
+			pool,
+			data.PrefixLength.ValueInt64(),
+			dim.IPPoolGetDelegationOptions{
+				Attributes: attributes,
+			},
+		)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Create", "ippool_get_delegation", err)
+			return
+		}
+		if len(dimResp) != 1 {
+			// not expected without maxsplit
+			resp.Diagnostics.AddError(
+				fmt.Sprintf(r.diagErrorSummaryTemplate(), "Create"),
+				fmt.Sprintf("Unexpected result from ippool_get_delegation: %d blocks allocated", len(dimResp)),
+			)
+			return
+		}
+		block = dimResp[0].IP
+		layer3domain = dimResp[0].Layer3domain
+	} else {
+		// will create the specific block in the layer3domain of the pool
+		// and check that it's within the pool
+		poolResp, err := r.client.IPPoolGetAttrs(ctx, pool)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Create", "ippool_get_attrs", err)
+			return
+		}
+		block = data.Block.ValueString()
+		layer3domain = poolResp.Layer3domain
+		err = r.client.IPBlockCreate(ctx,
+			block,
+			dim.IPBlockCreateOptions{
+				Status:       "Delegation",
+				Layer3domain: layer3domain,
+				Attributes:   attributes,
+			},
+		)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Create", "ipblock_create", err)
+			return
+		}
+	}
+	tflog.Info(ctx, "Block has been delegated", map[string]any{
+		"layer3domain": layer3domain,
+		"block":        block,
+	})
+
+	dimResp, err := r.client.IPBlockGetAttrs(ctx, block, dim.IPBlockOptions{Layer3domain: layer3domain})
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "ipblock_get_attrs", err)
+		return
+	}
+	if dimResp.Pool != pool {
+		// the block is outside of the pool, so undo the creation
+		err = r.client.IPBlockRemove(ctx,
+			block,
+			dim.IPBlockRemoveOptions{
+				IPBlockOptions: dim.IPBlockOptions{Layer3domain: layer3domain},
+				Status:         "Delegation",
+			},
+		)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Create", "ipblock_remove", err)
+		}
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Create"),
+			fmt.Sprintf("The block %s is not within the pool %s", block, pool),
+		)
+		return
+	}
+	data.readInDimResponse(dimResp)
+
+	// now when we know the all values, set the ID
+	data.ID = types.StringValue(data.composeID())
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ipDelegationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current data
+	var data ipDelegationResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := data.parseID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Read"),
+			err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("ID parsed %+v", id))
+
+	dimResp, err := r.client.IPBlockGetAttrs(ctx, id.block, dim.IPBlockOptions{Layer3domain: id.layer3domain})
+	if err != nil {
+		if _, ok := err.(dim.Error); ok {
+			if err.(dim.Error).Code == 1 {
+				tflog.Debug(ctx, fmt.Sprintf("block not found (has been freed?) %+v", id))
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		r.diagDimError(&resp.Diagnostics, "Read", "ipblock_get_attrs", err)
+		return
+	}
+	if dimResp.Status != "Delegation" {
+		tflog.Debug(ctx, fmt.Sprintf("the status of the block is not Delegation (has been freed?) %+v", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.readInDimResponse(dimResp)
+
+	tflog.Info(ctx, "Block has been read", map[string]any{
+		"layer3domain": data.Layer3domain.ValueString(),
+		"block":        data.Block.ValueString(),
+	})
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *ipDelegationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// only comment is updatable
+
+	var data ipDelegationResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, _ := data.parseID() // well we know it's valid, so no need to check the error
+	opts := dim.IPBlockOptions{Layer3domain: id.layer3domain}
+
+	err := r.client.IPBlockSetAttrs(ctx,
+		id.block,
+		map[string]string{
+			"comment": data.Comment.ValueString(),
+		},
+		opts,
+	)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "ipblock_set_attrs", err)
+		return
+	}
+
+	tflog.Info(ctx, "Block has been updated", map[string]any{
+		"layer3domain": id.layer3domain,
+		"block":        id.block,
+	})
+
+	// Read the updated attrs
+	dimResp, err := r.client.IPBlockGetAttrs(ctx, id.block, opts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "ipblock_get_attrs", err)
+		return
+	}
+	data.readInDimResponse(dimResp)
+
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *ipDelegationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from data
+	var data ipDelegationResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := data.parseID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Delete"),
+			err.Error(),
+		)
+		return
+	}
+
+	// not forced, so DIM refuses to free the block with allocated addresses
+	err = r.client.IPBlockRemove(ctx,
+		id.block,
+		dim.IPBlockRemoveOptions{
+			IPBlockOptions: dim.IPBlockOptions{
+				Layer3domain: id.layer3domain,
+				Pool:         data.Pool.ValueString(),
+			},
+			Status: "Delegation",
+		},
+	)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Delete", "ipblock_remove", err)
+		return
+	}
+}
+
+func (r *ipDelegationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestPrefixRange(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantFirst string
+		wantLast  string
+	}{
+		{input: "10.0.0.0/26", wantFirst: "10.0.0.0", wantLast: "10.0.0.63"},
+		{input: "10.0.1.5/23", wantFirst: "10.0.0.0", wantLast: "10.0.1.255"},
+		{input: "10.0.0.7/32", wantFirst: "10.0.0.7", wantLast: "10.0.0.7"},
+		{input: "2001:db8::/64", wantFirst: "2001:db8::", wantLast: "2001:db8::ffff:ffff:ffff:ffff"},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		first, last := prefixRange(netip.MustParsePrefix(tests[i].input))
+		if first.String() != tests[i].wantFirst || last.String() != tests[i].wantLast {
+			t.Errorf("prefixRange(%s) = %s, %s ; wants = %s, %s", tests[i].input, first, last, tests[i].wantFirst, tests[i].wantLast)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccIPResource(t *testing.T) {
+	s := testAccDIM(t)
+	c := testAccDIMClient(t, s)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckIPStatus(c, "10.88.8.20", "Available"),
+		Steps: []resource.TestStep{
+			// the DIM fields can not be used as attributes
+			{
+				Config: fmt.Sprintf(`
+resource "ionosdim_ip" "test" {
+  pool       = %q
+  attributes = { comment = "some comment" }
+}
+`, testAccPool),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccIPResourceConfig("", "some comment", "network"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ionosdim_ip.test", "id", "default/10.88.8.2"),
+					resource.TestCheckResourceAttr("ionosdim_ip.test", "ip", "10.88.8.2"),
+					resource.TestCheckResourceAttr("ionosdim_ip.test", "layer3domain", "default"),
+					resource.TestCheckResourceAttr("ionosdim_ip.test", "status", "Static"),
+					resource.TestCheckResourceAttr("ionosdim_ip.test", "subnet", testAccSubnet),
+					resource.TestCheckResourceAttr("ionosdim_ip.test", "gateway", "10.88.8.1"),
+					resource.TestCheckResourceAttr("ionosdim_ip.test", "comment", "some comment"),
+					resource.TestCheckResourceAttr("ionosdim_ip.test", "attributes.owner", "network"),
+					testAccCheckIPStatus(c, "10.88.8.2", "Static"),
+				),
+			},
+			// Update in place testing
+			{
+				Config: testAccIPResourceConfig("", "other comment", "dns"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ionosdim_ip.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ionosdim_ip.test", "ip", "10.88.8.2"),
+					resource.TestCheckResourceAttr("ionosdim_ip.test", "comment", "other comment"),
+					resource.TestCheckResourceAttr("ionosdim_ip.test", "attributes.owner", "dns"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ionosdim_ip.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the attributes are not read on import
+				ImportStateVerifyIgnore: []string{"attributes"},
+			},
+			// Replace testing
+			{
+				Config: testAccIPResourceConfig("10.88.8.20", "other comment", "dns"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ionosdim_ip.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ionosdim_ip.test", "id", "default/10.88.8.20"),
+					testAccCheckIPStatus(c, "10.88.8.2", "Available"),
+					testAccCheckIPStatus(c, "10.88.8.20", "Static"),
+				),
+			},
+			// Drift testing, the freed address is allocated again
+			{
+				PreConfig: func() {
+					if _, err := c.IPFree(context.Background(), "10.88.8.20", dim.IPBlockOptions{Host: true}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccIPResourceConfig("10.88.8.20", "other comment", "dns"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ionosdim_ip.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: testAccCheckIPStatus(c, "10.88.8.20", "Static"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccIPResourceConfig(ip, comment, owner string) string {
+	ipArg := ""
+	if ip != "" {
+		ipArg = fmt.Sprintf("ip = %q", ip)
+	}
+	return fmt.Sprintf(`
+resource "ionosdim_ip" "test" {
+  pool    = %[1]q
+  %[2]s
+  comment = %[3]q
+  attributes = {
+    owner = %[4]q
+  }
+}
+`, testAccPool, ipArg, comment, owner)
+}
+
+// testAccCheckIPStatus checks the status of the address in DIM
+func testAccCheckIPStatus(c *dim.Client, ip, status string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		attrs, err := c.IPBlockGetAttrs(context.Background(), ip, dim.IPBlockOptions{Host: true})
+		if err != nil {
+			return err
+		}
+		if attrs.Status != status {
+			return fmt.Errorf("status of %s is %s, expected %s", ip, attrs.Status, status)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,423 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &ipblockResource{}
+	_ resource.ResourceWithConfigure   = &ipblockResource{}
+	_ resource.ResourceWithImportState = &ipblockResource{}
+)
+
+func NewIPBlockResource() resource.Resource {
+	return &ipblockResource{}
+}
+
+type ipblockResource struct {
+	client *dim.Client
+}
+
+type ipblockResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Block        types.String `tfsdk:"block"`
+	Status       types.String `tfsdk:"status"`
+	Layer3domain types.String `tfsdk:"layer3domain"`
+
+	Comment    types.String `tfsdk:"comment"`
+	Attributes types.Map    `tfsdk:"attributes"`
+
+	Created    types.String `tfsdk:"created"` // no created_by field!
+	Modified   types.String `tfsdk:"modified"`
+	ModifiedBy types.String `tfsdk:"modified_by"`
+}
+
+type ipblockID struct {
+	layer3domain string
+	block        string
+}
+
+func (rm ipblockResourceModel) composeID() string {
+	// <layer3domain>/<block>, e.g. default/10.0.0.0/8
+	return rm.Layer3domain.ValueString() + "/" + rm.Block.ValueString()
+}
+
+func (rm ipblockResourceModel) parseID() (ipblockID, error) {
+	idParts := strings.SplitN(rm.ID.ValueString(), "/", 2)
+	if len(idParts) != 2 {
+		return ipblockID{}, fmt.Errorf("ID is not in expected format")
+	}
+	return ipblockID{
+		layer3domain: idParts[0],
+		block:        idParts[1],
+	}, nil
+}
+
+// dimAttributes returns the DIM attributes of the block
+// i.e. the user defined attributes and the comment
+func (rm ipblockResourceModel) dimAttributes(ctx context.Context) (map[string]string, diag.Diagnostics) {
+	attrs, diags := attributesFromMap(ctx, rm.Attributes)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if !(rm.Comment.IsNull() || rm.Comment.IsUnknown()) {
+		if attrs == nil {
+			attrs = map[string]string{}
+		}
+		attrs["comment"] = rm.Comment.ValueString()
+	}
+	return attrs, diags
+}
+
+// readInDimResponse parse the DIM response into the Resource Model,
+// the attributes missing in the response are left intact
+func (rm *ipblockResourceModel) readInDimResponse(ctx context.Context, dimResp *dim.IPBlockAttrs) diag.Diagnostics {
+	// keep the configured spelling of the block
+	if v := dimResp.IP; v != "" && !samePrefix(rm.Block.ValueString(), v) {
+		rm.Block = types.StringValue(v)
+	}
+	if v := dimResp.Layer3domain; v != "" {
+		rm.Layer3domain = types.StringValue(v)
+	}
+	if v := dimResp.Status; v != "" {
+		rm.Status = types.StringValue(v)
+	}
+	if v := dimResp.Created; v != "" {
+		rm.Created = types.StringValue(v)
+	}
+	if v := dimResp.Modified; v != "" {
+		rm.Modified = types.StringValue(v)
+	}
+	if v := dimResp.ModifiedBy; v != "" {
+		rm.ModifiedBy = types.StringValue(v)
+	}
+	if dimResp.Comment != nil {
+		rm.Comment = types.StringValue(*dimResp.Comment)
+	}
+
+	var diags diag.Diagnostics
+	rm.Attributes, diags = refreshAttributes(ctx, rm.Attributes, dimResp.Attributes)
+	return diags
+}
+
+func (r *ipblockResource) diagErrorSummaryTemplate() string {
+	return "Error in %s ipblock"
+}
+
+func (r *ipblockResource) diagErrorDetailTemplate() string {
+	return "Unexpected error from %s: %s"
+}
+
+// diagDimError adds the error returned by the DIM function dfunc to diags
+func (r *ipblockResource) diagDimError(diags *diag.Diagnostics, tfAction string, dfunc string, err error) {
+	diags.AddError(
+		fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
+		fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
+	)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ipblockResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *ipblockResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ipblock"
+}
+
+// Schema defines the schema for the resource.
+func (r *ipblockResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates an ip block (e.g. a container of subnets) in DIM.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"block": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					prefixValidator{},
+				},
+				MarkdownDescription: "the block in CIDR notation without host bits, e.g. `10.0.0.0/8`",
+			},
+			"status": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("Container", "Delegation"),
+				},
+				MarkdownDescription: "the status of the block, `Container` (DIM default) or `Delegation`",
+			},
+			"layer3domain": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the layer 3 domain of the block, optional if DIM has a single layer 3 domain",
+			},
+			"comment": schema.StringAttribute{
+				Optional: true,
+			},
+			"attributes": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.NoneOf(ipBlockReservedAttributes...)),
+				},
+				MarkdownDescription: "the user defined attributes of the block, the DIM fields, e.g. `comment` or `status`, can not be used as attributes",
+			},
+
+			"created": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified": schema.StringAttribute{
+				Computed: true,
+			},
+			"modified_by": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ipblockResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from data
+	var data ipblockResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	block := data.Block.ValueString()
+	createOpts := dim.IPBlockCreateOptions{}
+	// optional args
+	if !(data.Status.IsNull() || data.Status.IsUnknown()) {
+		createOpts.Status = data.Status.ValueString()
+	}
+	if !(data.Layer3domain.IsNull() || data.Layer3domain.IsUnknown()) {
+		createOpts.Layer3domain = data.Layer3domain.ValueString()
+	}
+	createOpts.Attributes, diags = data.dimAttributes(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.IPBlockCreate(ctx, block, createOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "ipblock_create", err)
+		return
+	}
+	tflog.Info(ctx, "IP block has been created", map[string]any{"block": block})
+
+	dimResp, err := r.client.IPBlockGetAttrs(ctx, block, dim.IPBlockOptions{Layer3domain: createOpts.Layer3domain})
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "ipblock_get_attrs", err)
+		return
+	}
+	resp.Diagnostics.Append(data.readInDimResponse(ctx, dimResp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// now when we know the all values, set the ID
+	data.ID = types.StringValue(data.composeID())
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ipblockResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current data
+	var data ipblockResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := data.parseID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Read"),
+			err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("ID parsed %+v", id))
+
+	dimResp, err := r.client.IPBlockGetAttrs(ctx, id.block, dim.IPBlockOptions{Layer3domain: id.layer3domain})
+	if err != nil {
+		if _, ok := err.(dim.Error); ok {
+			if err.(dim.Error).Code == 1 {
+				tflog.Debug(ctx, fmt.Sprintf("block not found (has been removed?) %+v", id))
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		r.diagDimError(&resp.Diagnostics, "Read", "ipblock_get_attrs", err)
+		return
+	}
+	resp.Diagnostics.Append(data.readInDimResponse(ctx, dimResp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "IP block has been read", map[string]any{"id": data.ID.ValueString()})
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *ipblockResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// comment and attributes are updatable
+	// other args result in resource replacement
+
+	var data, state ipblockResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, _ := state.parseID() // well we know it's valid, so no need to check the error
+	opts := dim.IPBlockOptions{Layer3domain: id.layer3domain}
+
+	newAttrs, diags := data.dimAttributes(ctx)
+	resp.Diagnostics.Append(diags...)
+	oldAttrs, diags := state.dimAttributes(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	setAttrs, delAttrs := diffAttributes(newAttrs, oldAttrs)
+	if len(setAttrs) > 0 {
+		err := r.client.IPBlockSetAttrs(ctx, id.block, setAttrs, opts)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Update", "ipblock_set_attrs", err)
+			return
+		}
+	}
+	if len(delAttrs) > 0 {
+		err := r.client.IPBlockDeleteAttrs(ctx, id.block, delAttrs, opts)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Update", "ipblock_delete_attrs", err)
+			return
+		}
+	}
+	tflog.Info(ctx, "IP block has been updated", map[string]any{"id": data.ID.ValueString()})
+
+	// Read the updated attrs
+	dimResp, err := r.client.IPBlockGetAttrs(ctx, id.block, opts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "ipblock_get_attrs", err)
+		return
+	}
+	resp.Diagnostics.Append(data.readInDimResponse(ctx, dimResp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *ipblockResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from data
+	var data ipblockResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := data.parseID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Delete"),
+			err.Error(),
+		)
+		return
+	}
+
+	// neither forced nor recursive, so DIM refuses to remove the block with children
+	err = r.client.IPBlockRemove(ctx,
+		id.block,
+		dim.IPBlockRemoveOptions{
+			IPBlockOptions: dim.IPBlockOptions{Layer3domain: id.layer3domain},
+			Status:         data.Status.ValueString(),
+		},
+	)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Delete", "ipblock_remove", err)
+		return
+	}
+}
+
+func (r *ipblockResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,383 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &ippoolResource{}
+	_ resource.ResourceWithConfigure   = &ippoolResource{}
+	_ resource.ResourceWithImportState = &ippoolResource{}
+)
+
+func NewIPPoolResource() resource.Resource {
+	return &ippoolResource{}
+}
+
+type ippoolResource struct {
+	client *dim.Client
+}
+
+type ippoolResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Layer3domain types.String `tfsdk:"layer3domain"`
+
+	VLAN        types.Int64  `tfsdk:"vlan"`
+	Description types.String `tfsdk:"description"`
+	Attributes  types.Map    `tfsdk:"attributes"`
+
+	Created    types.String `tfsdk:"created"` // no created_by field!
+	Modified   types.String `tfsdk:"modified"`
+	ModifiedBy types.String `tfsdk:"modified_by"`
+}
+
+// dimAttributes returns the DIM attributes of the pool
+// i.e. the user defined attributes and the description
+func (rm ippoolResourceModel) dimAttributes(ctx context.Context) (map[string]string, diag.Diagnostics) {
+	attrs, diags := attributesFromMap(ctx, rm.Attributes)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if !(rm.Description.IsNull() || rm.Description.IsUnknown()) {
+		if attrs == nil {
+			attrs = map[string]string{}
+		}
+		attrs["description"] = rm.Description.ValueString()
+	}
+	return attrs, diags
+}
+
+// readInDimResponse parse the DIM response into the Resource Model,
+// the attributes missing in the response are left intact
+func (rm *ippoolResourceModel) readInDimResponse(ctx context.Context, dimResp *dim.IPPoolAttrs) diag.Diagnostics {
+	if v := dimResp.Name; v != "" {
+		rm.Name = types.StringValue(v)
+	}
+	if v := dimResp.Layer3domain; v != "" {
+		rm.Layer3domain = types.StringValue(v)
+	}
+	if v := dimResp.Created; v != "" {
+		rm.Created = types.StringValue(v)
+	}
+	if v := dimResp.Modified; v != "" {
+		rm.Modified = types.StringValue(v)
+	}
+	if v := dimResp.ModifiedBy; v != "" {
+		rm.ModifiedBy = types.StringValue(v)
+	}
+
+	if dimResp.VLAN != nil {
+		rm.VLAN = types.Int64Value(*dimResp.VLAN)
+	}
+	if dimResp.Description != nil {
+		rm.Description = types.StringValue(*dimResp.Description)
+	}
+
+	var diags diag.Diagnostics
+	rm.Attributes, diags = refreshAttributes(ctx, rm.Attributes, dimResp.Attributes)
+	return diags
+}
+
+func (r *ippoolResource) diagErrorSummaryTemplate() string {
+	return "Error in %s ippool"
+}
+
+func (r *ippoolResource) diagErrorDetailTemplate() string {
+	return "Unexpected error from %s: %s"
+}
+
+// diagDimError adds the error returned by the DIM function dfunc to diags
+func (r *ippoolResource) diagDimError(diags *diag.Diagnostics, tfAction string, dfunc string, err error) {
+	diags.AddError(
+		fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
+		fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
+	)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ippoolResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *ippoolResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ippool"
+}
+
+// Schema defines the schema for the resource.
+func (r *ippoolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates an ip pool in DIM. The subnets are added to the pool by `ionosdim_ippool_subnet`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "the name of the pool",
+			},
+			"layer3domain": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the layer 3 domain of the pool, optional if DIM has a single layer 3 domain",
+			},
+			"vlan": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 4094),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"attributes": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.NoneOf(ipPoolReservedAttributes...)),
+				},
+				MarkdownDescription: "the user defined attributes of the pool, the DIM fields, e.g. `description` or `vlan`, can not be used as attributes",
+			},
+
+			"created": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified": schema.StringAttribute{
+				Computed: true,
+			},
+			"modified_by": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ippoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from data
+	var data ippoolResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+	createOpts := dim.IPPoolCreateOptions{}
+	// optional args
+	if !(data.Layer3domain.IsNull() || data.Layer3domain.IsUnknown()) {
+		createOpts.Layer3domain = data.Layer3domain.ValueString()
+	}
+	if !(data.VLAN.IsNull() || data.VLAN.IsUnknown()) {
+		createOpts.VLAN = data.VLAN.ValueInt64Pointer()
+	}
+	createOpts.Attributes, diags = data.dimAttributes(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.IPPoolCreate(ctx, name, createOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "ippool_create", err)
+		return
+	}
+	tflog.Info(ctx, "Pool has been created", map[string]any{"name": name})
+
+	dimResp, err := r.client.IPPoolGetAttrs(ctx, name)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "ippool_get_attrs", err)
+		return
+	}
+	resp.Diagnostics.Append(data.readInDimResponse(ctx, dimResp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(name)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ippoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current data
+	var data ippoolResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.ID.ValueString()
+
+	dimResp, err := r.client.IPPoolGetAttrs(ctx, name)
+	if err != nil {
+		if _, ok := err.(dim.Error); ok {
+			if err.(dim.Error).Code == 1 {
+				tflog.Debug(ctx, fmt.Sprintf("pool not found (has been removed?) %s", name))
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		r.diagDimError(&resp.Diagnostics, "Read", "ippool_get_attrs", err)
+		return
+	}
+	resp.Diagnostics.Append(data.readInDimResponse(ctx, dimResp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "Pool has been read", map[string]any{"name": name})
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *ippoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// vlan, description and attributes are updatable
+	// name and layer3domain result in resource replacement
+
+	var data, state ippoolResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	if !data.VLAN.Equal(state.VLAN) {
+		if data.VLAN.IsNull() {
+			if err := r.client.IPPoolRemoveVLAN(ctx, name); err != nil {
+				r.diagDimError(&resp.Diagnostics, "Update", "ippool_remove_vlan", err)
+				return
+			}
+		} else {
+			if err := r.client.IPPoolSetVLAN(ctx, name, data.VLAN.ValueInt64()); err != nil {
+				r.diagDimError(&resp.Diagnostics, "Update", "ippool_set_vlan", err)
+				return
+			}
+		}
+	}
+
+	newAttrs, diags := data.dimAttributes(ctx)
+	resp.Diagnostics.Append(diags...)
+	oldAttrs, diags := state.dimAttributes(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	setAttrs, delAttrs := diffAttributes(newAttrs, oldAttrs)
+	if len(setAttrs) > 0 {
+		err := r.client.IPPoolSetAttrs(ctx, name, setAttrs)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Update", "ippool_set_attrs", err)
+			return
+		}
+	}
+	if len(delAttrs) > 0 {
+		err := r.client.IPPoolDeleteAttrs(ctx, name, delAttrs)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Update", "ippool_delete_attrs", err)
+			return
+		}
+	}
+	tflog.Info(ctx, "Pool has been updated", map[string]any{"name": name})
+
+	// Read the updated attrs
+	dimResp, err := r.client.IPPoolGetAttrs(ctx, name)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "ippool_get_attrs", err)
+		return
+	}
+	resp.Diagnostics.Append(data.readInDimResponse(ctx, dimResp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *ippoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from data
+	var data ippoolResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the subnets are expected to be removed by ionosdim_ippool_subnet,
+	// so the pool is not deleted forcibly
+	err := r.client.IPPoolDelete(ctx, data.ID.ValueString(), dim.IPPoolDeleteOptions{})
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Delete", "ippool_delete", err)
+		return
+	}
+}
+
+func (r *ippoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
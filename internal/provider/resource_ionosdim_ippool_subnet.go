@@ -0,0 +1,382 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &ippoolSubnetResource{}
+	_ resource.ResourceWithConfigure   = &ippoolSubnetResource{}
+	_ resource.ResourceWithImportState = &ippoolSubnetResource{}
+)
+
+func NewIPPoolSubnetResource() resource.Resource {
+	return &ippoolSubnetResource{}
+}
+
+type ippoolSubnetResource struct {
+	client *dim.Client
+}
+
+type ippoolSubnetResourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Pool   types.String `tfsdk:"pool"`
+	Subnet types.String `tfsdk:"subnet"`
+
+	Gateway                     types.String `tfsdk:"gateway"`
+	DontReserveNetworkBroadcast types.Bool   `tfsdk:"dont_reserve_network_broadcast"`
+
+	Layer3domain types.String `tfsdk:"layer3domain"`
+	Mask         types.String `tfsdk:"mask"`
+	Created      types.String `tfsdk:"created"` // no created_by field!
+	Modified     types.String `tfsdk:"modified"`
+	ModifiedBy   types.String `tfsdk:"modified_by"`
+}
+
+type ippoolSubnetID struct {
+	pool   string
+	subnet string
+}
+
+func (rm ippoolSubnetResourceModel) composeID() string {
+	// <pool>/<subnet>, e.g. some-pool/10.0.0.0/24
+	return rm.Pool.ValueString() + "/" + rm.Subnet.ValueString()
+}
+
+func (rm ippoolSubnetResourceModel) parseID() (ippoolSubnetID, error) {
+	idParts := strings.SplitN(rm.ID.ValueString(), "/", 2)
+	if len(idParts) != 2 {
+		return ippoolSubnetID{}, fmt.Errorf("ID is not in expected format")
+	}
+	return ippoolSubnetID{
+		pool:   idParts[0],
+		subnet: idParts[1],
+	}, nil
+}
+
+// ipblockOptions returns the options selecting the subnet
+func (rm ippoolSubnetResourceModel) ipblockOptions() dim.IPBlockOptions {
+	opts := dim.IPBlockOptions{}
+	if !(rm.Layer3domain.IsNull() || rm.Layer3domain.IsUnknown()) {
+		opts.Layer3domain = rm.Layer3domain.ValueString()
+	}
+	return opts
+}
+
+// readInDimResponse parse the DIM response into the Resource Model,
+// the attributes missing in the response are left intact
+func (rm *ippoolSubnetResourceModel) readInDimResponse(dimResp *dim.IPBlockAttrs) {
+	if v := dimResp.Pool; v != "" {
+		rm.Pool = types.StringValue(v)
+	}
+	if v := dimResp.Layer3domain; v != "" {
+		rm.Layer3domain = types.StringValue(v)
+	}
+	if v := dimResp.Gateway; v != "" {
+		rm.Gateway = types.StringValue(v)
+	}
+	if v := dimResp.Mask; v != "" {
+		rm.Mask = types.StringValue(v)
+	}
+	if v := dimResp.Created; v != "" {
+		rm.Created = types.StringValue(v)
+	}
+	if v := dimResp.Modified; v != "" {
+		rm.Modified = types.StringValue(v)
+	}
+	if v := dimResp.ModifiedBy; v != "" {
+		rm.ModifiedBy = types.StringValue(v)
+	}
+}
+
+func (r *ippoolSubnetResource) diagErrorSummaryTemplate() string {
+	return "Error in %s ippool subnet"
+}
+
+func (r *ippoolSubnetResource) diagErrorDetailTemplate() string {
+	return "Unexpected error from %s: %s"
+}
+
+// diagDimError adds the error returned by the DIM function dfunc to diags
+func (r *ippoolSubnetResource) diagDimError(diags *diag.Diagnostics, tfAction string, dfunc string, err error) {
+	diags.AddError(
+		fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
+		fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
+	)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ippoolSubnetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *ippoolSubnetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ippool_subnet"
+}
+
+// Schema defines the schema for the resource.
+func (r *ippoolSubnetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Adds a subnet to an ip pool in DIM.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"pool": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "the name of the pool",
+			},
+			"subnet": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "the subnet in CIDR notation, e.g. `10.0.0.0/24`",
+			},
+			"gateway": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the gateway of the subnet, removing it from the configuration keeps the gateway of the subnet in DIM",
+			},
+			"dont_reserve_network_broadcast": schema.BoolAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "do not reserve the network and broadcast addresses of the subnet",
+			},
+
+			"layer3domain": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the layer 3 domain of the pool",
+			},
+			"mask": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified": schema.StringAttribute{
+				Computed: true,
+			},
+			"modified_by": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ippoolSubnetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from data
+	var data ippoolSubnetResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addOpts := dim.IPPoolAddSubnetOptions{}
+	// optional args
+	if !(data.Gateway.IsNull() || data.Gateway.IsUnknown()) {
+		addOpts.Gateway = data.Gateway.ValueString()
+	}
+	if !(data.DontReserveNetworkBroadcast.IsNull() || data.DontReserveNetworkBroadcast.IsUnknown()) {
+		addOpts.DontReserveNetworkBroadcast = data.DontReserveNetworkBroadcast.ValueBool()
+	}
+
+	err := r.client.IPPoolAddSubnet(ctx, data.Pool.ValueString(), data.Subnet.ValueString(), addOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "ippool_add_subnet", err)
+		return
+	}
+	data.ID = types.StringValue(data.composeID())
+	tflog.Info(ctx, "Subnet has been added to the pool", map[string]any{"id": data.ID.ValueString()})
+
+	// the subnet is in the layer3domain of the pool
+	dimResp, err := r.client.IPBlockGetAttrs(ctx, data.Subnet.ValueString(), dim.IPBlockOptions{Pool: data.Pool.ValueString()})
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "ipblock_get_attrs", err)
+		return
+	}
+	data.readInDimResponse(dimResp)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ippoolSubnetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current data
+	var data ippoolSubnetResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := data.parseID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Read"),
+			err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("ID parsed %+v", id))
+	// e.g. after import
+	data.Subnet = types.StringValue(id.subnet)
+
+	opts := data.ipblockOptions()
+	opts.Pool = id.pool
+	dimResp, err := r.client.IPBlockGetAttrs(ctx, id.subnet, opts)
+	if err != nil {
+		if _, ok := err.(dim.Error); ok {
+			if err.(dim.Error).Code == 1 {
+				tflog.Debug(ctx, fmt.Sprintf("subnet not found (has been removed?) %+v", id))
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		r.diagDimError(&resp.Diagnostics, "Read", "ipblock_get_attrs", err)
+		return
+	}
+	if dimResp.Status != "Subnet" || dimResp.Pool != id.pool {
+		tflog.Debug(ctx, fmt.Sprintf("the block is not a subnet of the pool (has been removed?) %+v", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.readInDimResponse(dimResp)
+	tflog.Info(ctx, "Subnet has been read", map[string]any{"id": data.ID.ValueString()})
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *ippoolSubnetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// only gateway is updatable
+
+	var data ippoolSubnetResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := data.ipblockOptions()
+	// a gateway removed from the configuration is left to DIM, i.e. not sent
+	if !(data.Gateway.IsNull() || data.Gateway.IsUnknown()) {
+		err := r.client.IPBlockSetAttrs(ctx,
+			data.Subnet.ValueString(),
+			map[string]string{
+				"gateway": data.Gateway.ValueString(),
+			},
+			opts,
+		)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Update", "ipblock_set_attrs", err)
+			return
+		}
+		tflog.Info(ctx, "Subnet has been updated", map[string]any{"id": data.ID.ValueString(), "gateway": data.Gateway.ValueString()})
+	}
+
+	// Read the updated attrs
+	dimResp, err := r.client.IPBlockGetAttrs(ctx, data.Subnet.ValueString(), opts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "ipblock_get_attrs", err)
+		return
+	}
+	data.readInDimResponse(dimResp)
+
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *ippoolSubnetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from data
+	var data ippoolSubnetResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := dim.IPBlockRemoveOptions{
+		IPBlockOptions: data.ipblockOptions(),
+		Status:         "Subnet",
+	}
+	opts.Pool = data.Pool.ValueString()
+	// not forced, so DIM refuses to remove the subnet with allocated addresses
+	err := r.client.IPBlockRemove(ctx, data.Subnet.ValueString(), opts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Delete", "ipblock_remove", err)
+		return
+	}
+}
+
+func (r *ippoolSubnetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
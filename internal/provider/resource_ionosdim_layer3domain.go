@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &layer3domainResource{}
+	_ resource.ResourceWithConfigure   = &layer3domainResource{}
+	_ resource.ResourceWithImportState = &layer3domainResource{}
+)
+
+func NewLayer3domainResource() resource.Resource {
+	return &layer3domainResource{}
+}
+
+type layer3domainResource struct {
+	client *dim.Client
+}
+
+type layer3domainResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	RD      types.String `tfsdk:"rd"`
+	Comment types.String `tfsdk:"comment"`
+}
+
+// readInDimResponse parse the DIM response into the Resource Model,
+// the attributes missing in the response are left intact
+func (rm *layer3domainResourceModel) readInDimResponse(dimResp *dim.Layer3domain) {
+	if v := dimResp.Name; v != "" {
+		rm.Name = types.StringValue(v)
+	}
+	if v := dimResp.Type; v != "" {
+		rm.Type = types.StringValue(v)
+	}
+	if v := dimResp.RD; v != "" {
+		rm.RD = types.StringValue(v)
+	}
+	if dimResp.Comment != nil {
+		rm.Comment = types.StringValue(*dimResp.Comment)
+	}
+}
+
+// findLayer3domain returns the layer3domain of the name, nil if there is no such layer3domain.
+// DIM has no function returning a single layer3domain, so the whole list is searched
+func findLayer3domain(ctx context.Context, client *dim.Client, name string) (*dim.Layer3domain, error) {
+	dimResp, err := client.Layer3domainList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range dimResp {
+		if dimResp[i].Name == name {
+			return &dimResp[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *layer3domainResource) diagErrorSummaryTemplate() string {
+	return "Error in %s layer3domain"
+}
+
+func (r *layer3domainResource) diagErrorDetailTemplate() string {
+	return "Unexpected error from %s: %s"
+}
+
+// diagDimError adds the error returned by the DIM function dfunc to diags
+func (r *layer3domainResource) diagDimError(diags *diag.Diagnostics, tfAction string, dfunc string, err error) {
+	diags.AddError(
+		fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
+		fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
+	)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *layer3domainResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *layer3domainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_layer3domain"
+}
+
+// Schema defines the schema for the resource.
+func (r *layer3domainResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a layer 3 domain (e.g. a VRF) in DIM. Changing the `name` renames the layer 3 domain in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true, // changes on rename, so no UseStateForUnknown
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "the name of the layer 3 domain",
+			},
+			"type": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "the type of the layer 3 domain, e.g. `vrf`",
+			},
+			"rd": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "the route distinguisher, e.g. `8560:1`, required by the `vrf` type",
+			},
+			"comment": schema.StringAttribute{
+				Optional: true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *layer3domainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from data
+	var data layer3domainResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+	createOpts := dim.Layer3domainCreateOptions{}
+	// optional args
+	if !(data.RD.IsNull() || data.RD.IsUnknown()) {
+		createOpts.RD = data.RD.ValueString()
+	}
+	if !(data.Comment.IsNull() || data.Comment.IsUnknown()) {
+		createOpts.Comment = data.Comment.ValueString()
+	}
+
+	err := r.client.Layer3domainCreate(ctx, name, data.Type.ValueString(), createOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "layer3domain_create", err)
+		return
+	}
+	tflog.Info(ctx, "Layer3domain has been created", map[string]any{"name": name})
+
+	data.ID = types.StringValue(name)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *layer3domainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current data
+	var data layer3domainResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.ID.ValueString()
+
+	dimResp, err := findLayer3domain(ctx, r.client, name)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Read", "layer3domain_list", err)
+		return
+	}
+	if dimResp == nil {
+		tflog.Debug(ctx, fmt.Sprintf("layer3domain not found (has been removed?) %s", name))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.readInDimResponse(dimResp)
+	tflog.Info(ctx, "Layer3domain has been read", map[string]any{"name": name})
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *layer3domainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// name (the layer3domain is renamed) and comment are updatable
+	// other args result in resource replacement
+
+	var data, state layer3domainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := state.ID.ValueString()
+	if newName := data.Name.ValueString(); newName != name {
+		err := r.client.Layer3domainRename(ctx, name, newName)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Update", "layer3domain_rename", err)
+			return
+		}
+		name = newName
+	}
+	if !data.Comment.Equal(state.Comment) {
+		err := r.client.Layer3domainSetAttrs(ctx,
+			name,
+			map[string]string{
+				"comment": data.Comment.ValueString(),
+			},
+		)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Update", "layer3domain_set_attrs", err)
+			return
+		}
+	}
+	data.ID = types.StringValue(name)
+	tflog.Info(ctx, "Layer3domain has been updated", map[string]any{"name": name})
+
+	diags := resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *layer3domainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from data
+	var data layer3domainResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Layer3domainDelete(ctx, data.ID.ValueString())
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Delete", "layer3domain_delete", err)
+		return
+	}
+}
+
+func (r *layer3domainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,541 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &mxRecordResource{}
+	_ resource.ResourceWithConfigure   = &mxRecordResource{}
+	_ resource.ResourceWithImportState = &mxRecordResource{}
+)
+
+func NewMXRecordResource() resource.Resource {
+	return &mxRecordResource{}
+}
+
+type mxRecordResource struct {
+	client *dim.Client
+}
+
+type mxRecordResourceModel struct {
+	// common identifying RR attributes
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Zone types.String `tfsdk:"zone"`
+	View types.String `tfsdk:"view"` // DIM api function rr_create has "views" plural, allowing to specify multiple view at one call. We stick to 1:1 terraform:real_world mapping
+	// MX record specific identifying attributes
+	Preference types.Int64  `tfsdk:"preference"`
+	Exchange   types.String `tfsdk:"exchange"`
+	// common non-identifying changeable attributes
+	Comment types.String `tfsdk:"comment"`
+	TTL     types.Int64  `tfsdk:"ttl"`
+	// common computed attributes
+	Created    types.String `tfsdk:"created"`
+	CreatedBy  types.String `tfsdk:"created_by"`
+	Modified   types.String `tfsdk:"modified"`
+	ModifiedBy types.String `tfsdk:"modified_by"`
+	RR         types.String `tfsdk:"rr"`
+}
+
+type mxRecordID struct {
+	zone       string
+	view       string
+	name       string
+	preference int64
+	exchange   string
+}
+
+func newMxRecordIDFromString(s string) (*mxRecordID, error) {
+	idParts := strings.SplitN(s, "/", 5)
+	if len(idParts) != 5 {
+		return nil, fmt.Errorf("ID is not in expected format")
+	}
+	preference, err := strconv.ParseInt(idParts[3], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("ID is not in expected format, preference part is not a number")
+	}
+	return &mxRecordID{
+		zone:       idParts[0],
+		view:       idParts[1],
+		name:       idParts[2],
+		preference: preference,
+		exchange:   idParts[4],
+	}, nil
+
+}
+
+func newMxRecordIDFromTfModel(ctx context.Context, m mxRecordResourceModel) (*mxRecordID, error) {
+	return &mxRecordID{
+		zone:       m.Zone.ValueString(),
+		view:       m.View.ValueString(),
+		name:       m.Name.ValueString(),
+		preference: m.Preference.ValueInt64(),
+		exchange:   m.Exchange.ValueString(),
+	}, nil
+}
+
+func (id mxRecordID) String() string {
+	// <zone>/<view>/<name>/<preference>/<exchange>
+	return fmt.Sprintf("%s/%s/%s/%d/%s", id.zone, id.view, id.name, id.preference, id.exchange)
+}
+
+func (id mxRecordID) getFqdn() string {
+	if name := id.name; strings.HasSuffix(name, ".") {
+		return name
+	} else {
+		return fmt.Sprintf("%s.%s.", name, id.zone)
+	}
+}
+
+// copyIDToModel set attributes in the model to values
+// from which the ID was composed
+func (r mxRecordResource) restoreIDAttributesToModel(ctx context.Context, id mxRecordID, rm *mxRecordResourceModel) error {
+	// optional args
+	if id.zone != "" {
+		rm.Zone = types.StringValue(id.zone)
+	} else {
+		rm.Zone = types.StringNull()
+	}
+	if id.view != "" {
+		rm.View = types.StringValue(id.view)
+	} else {
+		rm.View = types.StringNull()
+	}
+	// required args
+	rm.Name = types.StringValue(id.name)
+	rm.Preference = types.Int64Value(id.preference)
+	rm.Exchange = types.StringValue(id.exchange)
+	return nil
+}
+
+// readInDimResponse parse the DIM response into the Resource Model,
+// the attributes missing in the response are left intact
+func (r mxRecordResource) readInDimResponse(dimResp *dim.RRAttrs, rm *mxRecordResourceModel) {
+	if v := dimResp.Created; v != "" {
+		rm.Created = types.StringValue(v)
+	}
+	if v := dimResp.CreatedBy; v != "" {
+		rm.CreatedBy = types.StringValue(v)
+	}
+	if v := dimResp.Modified; v != "" {
+		rm.Modified = types.StringValue(v)
+	}
+	if v := dimResp.ModifiedBy; v != "" {
+		rm.ModifiedBy = types.StringValue(v)
+	}
+	if v := dimResp.RR; v != "" {
+		rm.RR = types.StringValue(v)
+	}
+
+	if v := dimResp.Zone; v != "" {
+		rm.Zone = types.StringValue(v)
+	}
+
+	// read other OPTIONAl attributes
+	if dimResp.TTL != nil {
+		rm.TTL = types.Int64Value(*dimResp.TTL)
+	}
+	if dimResp.Comment != nil {
+		rm.Comment = types.StringValue(*dimResp.Comment)
+	}
+
+	// not confirmed, whether this attributes are returned
+	if dimResp.View != nil {
+		rm.View = types.StringValue(*dimResp.View)
+	}
+}
+
+func (r *mxRecordResource) diagErrorSummaryTemplate() string {
+	return "Error in %s MX record"
+}
+
+func (r *mxRecordResource) diagErrorDetailTemplate() string {
+	return "Unexpected error from %s: %s"
+}
+
+// diagDimError adds the error returned by the DIM function dfunc to diags
+func (r *mxRecordResource) diagDimError(diags *diag.Diagnostics, tfAction string, dfunc string, err error) {
+	diags.AddError(
+		fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
+		fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
+	)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *mxRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *mxRecordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mx_record"
+}
+
+// Schema defines the schema for the resource.
+func (r *mxRecordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a MX type record in DIM.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "the fqdn of the RR or the relative name if zone was specified",
+			},
+			"zone": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "optional if name is a fqdn",
+			},
+			"view": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"preference": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(0, 65535),
+				},
+				MarkdownDescription: "the preference of the mail exchange, lower values are preferred",
+			},
+			"exchange": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`\.$`), "must be a fqdn with trailing dot"),
+				},
+				MarkdownDescription: "the fqdn (with trailing dot) of the mail exchange",
+			},
+
+			"comment": schema.StringAttribute{
+				Optional: true,
+			},
+			"ttl": schema.Int64Attribute{
+				Optional: true,
+			},
+
+			"created": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_by": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified": schema.StringAttribute{
+				Computed: true,
+			},
+			"modified_by": schema.StringAttribute{
+				Computed: true,
+			},
+			"rr": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *mxRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Create a new resource.
+	// Retrieve values from data
+	var data mxRecordResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// required args
+	id, err := newMxRecordIDFromTfModel(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Create"),
+			fmt.Sprintf("Unable to compose ID from Resource Model: %s", err.Error()),
+		)
+		return
+	}
+
+	dimRR := dim.RR{
+		Type:       "MX",
+		Name:       id.name,
+		Preference: &id.preference,
+		Exchange:   id.exchange,
+	}
+	// optional args
+	// see https://developer.hashicorp.com/terraform/plugin/framework/handling-data/accessing-values#when-can-a-value-be-unknown-or-null
+
+	// optional, computed
+	if !(data.Zone.IsNull() || data.Zone.IsUnknown()) {
+		dimRR.Zone = id.zone
+	}
+	// optional
+	if !(data.View.IsNull() || data.View.IsUnknown()) {
+		dimRR.View = id.view
+	}
+	createOpts := dim.RRCreateOptions{}
+	// optional
+	if !(data.Comment.IsNull() || data.Comment.IsUnknown()) {
+		createOpts.Comment = data.Comment.ValueStringPointer()
+	}
+	// optional
+	if !(data.TTL.IsNull() || data.TTL.IsUnknown()) {
+		createOpts.TTL = data.TTL.ValueInt64Pointer()
+	}
+
+	err = r.client.RRCreate(ctx, dimRR, createOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "rr_create", err)
+		return
+	}
+	tflog.Info(ctx, "RR has been created", map[string]any{"id": id.String()})
+
+	//rr_get_attrs
+	// rr_get_attrs accepts the subset of the args of rr_create
+	dimRR.Zone = ""
+	dimRR.Name = id.getFqdn() // rr_get_attrs has no "zone" arg, so "name" arg must be fqdn
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "rr_get_attrs", err)
+		return
+	}
+	r.readInDimResponse(dimResp, &data)
+
+	// now when we know the all values, set the ID
+	data.ID = types.StringValue(id.String())
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *mxRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current data
+	var data mxRecordResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := newMxRecordIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Read"),
+			err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("ID parsed %+v", id))
+	r.restoreIDAttributesToModel(ctx, *id, &data)
+
+	// required args
+	dimRR := dim.RR{
+		Type:       "MX",
+		Name:       id.getFqdn(), // rr_get_attrs accepts no "zone" arg, so "name" must be fqdn with trailing dot
+		Preference: &id.preference,
+		Exchange:   id.exchange,
+	}
+	// optional args
+	// we do not check if !data.View.IsNull(), because the state might be null
+	// , e.g, after import
+	if id.view != "" {
+		dimRR.View = id.view
+	}
+
+	tflog.Info(ctx, "Will read RR", map[string]any{"id": id.String()})
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
+	if err != nil {
+		if _, ok := err.(dim.Error); ok {
+			if err.(dim.Error).Code == 1 {
+				tflog.Debug(ctx, fmt.Sprintf("record not found (has been removed?) %+v", id))
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		r.diagDimError(&resp.Diagnostics, "Read", "rr_get_attrs", err)
+		return
+	}
+	r.readInDimResponse(dimResp, &data)
+	tflog.Info(ctx, "RR has been read", map[string]any{"id": id.String()})
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *mxRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// only TTL and comment are updatable
+	// other args result in resource replacement
+
+	// Retrieve values from data
+	var data mxRecordResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// required args
+	id, err := newMxRecordIDFromTfModel(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Update"),
+			fmt.Sprintf("Unable to compose ID from Resource Model: %s", err.Error()),
+		)
+		return
+	}
+	dimRR := dim.RR{
+		Type:       "MX",
+		Name:       id.getFqdn(), // rr_set_attrs has no "zone" attr, so "name" must be fqdn with trailing dot
+		Preference: &id.preference,
+		Exchange:   id.exchange,
+	}
+	// optional args
+	if !data.View.IsNull() {
+		dimRR.View = id.view
+	}
+	//updatable args
+	setOpts := dim.RRSetAttrsOptions{}
+	if !data.TTL.IsNull() {
+		setOpts.TTL = data.TTL.ValueInt64Pointer()
+	}
+	if !data.Comment.IsNull() {
+		setOpts.Comment = data.Comment.ValueStringPointer()
+	}
+
+	tflog.Info(ctx, "Will update RR", map[string]any{"id": id.String()})
+	err = r.client.RRSetAttrs(ctx, dimRR, setOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "rr_set_attrs", err)
+		return
+	}
+	tflog.Info(ctx, "RR has been updated", map[string]any{"id": id.String()})
+
+	// Read the updated attrs
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "rr_get_attrs", err)
+		return
+	}
+	r.readInDimResponse(dimResp, &data)
+
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *mxRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from data
+	var data mxRecordResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := newMxRecordIDFromTfModel(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Delete"),
+			fmt.Sprintf("Unable to compose ID from Resource Model: %s", err.Error()),
+		)
+		return
+	}
+
+	// required args
+	dimRR := dim.RR{
+		Type:       "MX",
+		Name:       id.name,
+		Preference: &id.preference,
+		Exchange:   id.exchange,
+	}
+	// optional args
+	if id.zone != "" {
+		dimRR.Zone = id.zone
+	}
+	if id.view != "" {
+		dimRR.View = id.view
+	}
+
+	err = r.client.RRDelete(ctx, dimRR, dim.RRDeleteOptions{References: "warn"})
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Delete", "rr_delete", err)
+		return
+	}
+}
+
+func (r *mxRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
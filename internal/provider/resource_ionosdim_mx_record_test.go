@@ -0,0 +1,20 @@
+package provider
+
+import "testing"
+
+func TestMxRecordIDRoundTrip(t *testing.T) {
+	s := "example.com//@/10/mx-01.example.com."
+	id, err := newMxRecordIDFromString(s)
+	if err != nil {
+		t.Fatalf("newMxRecordIDFromString(%q) error: %s", s, err)
+	}
+	if got := id.String(); got != s {
+		t.Errorf("newMxRecordIDFromString(%q).String() = %q", s, got)
+	}
+	if _, err := newMxRecordIDFromString("example.com//@/x/mx-01.example.com."); err == nil {
+		t.Errorf("newMxRecordIDFromString() accepted not a number preference")
+	}
+	if _, err := newMxRecordIDFromString("example.com//@/10"); err == nil {
+		t.Errorf("newMxRecordIDFromString() accepted an ID without exchange")
+	}
+}
@@ -0,0 +1,602 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &ptrRecordResource{}
+	_ resource.ResourceWithConfigure   = &ptrRecordResource{}
+	_ resource.ResourceWithImportState = &ptrRecordResource{}
+)
+
+func NewPTRRecordResource() resource.Resource {
+	return &ptrRecordResource{}
+}
+
+type ptrRecordResource struct {
+	client *dim.Client
+}
+
+type ptrRecordResourceModel struct {
+	// common identifying RR attributes
+	ID           types.String `tfsdk:"id"`
+	Layer3domain types.String `tfsdk:"layer3domain"`
+	View         types.String `tfsdk:"view"` // DIM api function rr_create has "views" plural, allowing to specify multiple view at one call. We stick to 1:1 terraform:real_world mapping
+	// PTR record specific identifying attributes
+	Ip       types.String `tfsdk:"ip"`
+	Ptrdname types.String `tfsdk:"ptrdname"`
+	// common non-identifying changeable attributes
+	Comment types.String `tfsdk:"comment"`
+	TTL     types.Int64  `tfsdk:"ttl"`
+	// computed from the ip
+	Name types.String `tfsdk:"name"`
+	Zone types.String `tfsdk:"zone"`
+	// common computed attributes
+	Created    types.String `tfsdk:"created"`
+	CreatedBy  types.String `tfsdk:"created_by"`
+	Modified   types.String `tfsdk:"modified"`
+	ModifiedBy types.String `tfsdk:"modified_by"`
+	RR         types.String `tfsdk:"rr"`
+}
+
+type ptrRecordID struct {
+	view         string
+	layer3domain string
+	ip           string
+	ptrdname     string
+}
+
+func newPtrRecordIDFromString(s string) (*ptrRecordID, error) {
+	idParts := strings.SplitN(s, "/", 4)
+	if len(idParts) != 4 {
+		return nil, fmt.Errorf("ID is not in expected format")
+	}
+	// the ID might be specified by user on import
+	ip, err := netip.ParseAddr(idParts[2])
+	if err != nil {
+		return nil, fmt.Errorf("ID is not in expected format, %q is not an IP address", idParts[2])
+	}
+	return &ptrRecordID{
+		view:         idParts[0],
+		layer3domain: idParts[1],
+		ip:           ip.String(),
+		ptrdname:     idParts[3],
+	}, nil
+}
+
+func newPtrRecordIDFromTfModel(ctx context.Context, m ptrRecordResourceModel) (*ptrRecordID, error) {
+	// the ID holds the normalized form of the address
+	ip, err := netip.ParseAddr(m.Ip.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("%q is not an IP address", m.Ip.ValueString())
+	}
+	return &ptrRecordID{
+		view:         m.View.ValueString(),
+		layer3domain: m.Layer3domain.ValueString(),
+		ip:           ip.String(),
+		ptrdname:     m.Ptrdname.ValueString(),
+	}, nil
+}
+
+func (id ptrRecordID) String() string {
+	// <view>/<layer3domain>/<ip>/<ptrdname>
+	return fmt.Sprintf("%s/%s/%s/%s", id.view, id.layer3domain, id.ip, id.ptrdname)
+}
+
+// getFqdn returns the name of the PTR record,
+// i.e. the reverse mapping name of the ip with trailing dot
+func (id ptrRecordID) getFqdn() string {
+	ip := netip.MustParseAddr(id.ip) // the ID always holds a valid address
+	if ip.Is4() {
+		b := ip.As4()
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa.", b[3], b[2], b[1], b[0])
+	}
+	b := ip.As16()
+	nibbles := make([]string, 0, 32)
+	for i := len(b) - 1; i >= 0; i-- {
+		nibbles = append(nibbles, fmt.Sprintf("%x", b[i]&0x0f), fmt.Sprintf("%x", b[i]>>4))
+	}
+	return strings.Join(nibbles, ".") + ".ip6.arpa."
+}
+
+// copyIDToModel set attributes in the model to values
+// from which the ID was composed
+func (r ptrRecordResource) restoreIDAttributesToModel(ctx context.Context, id ptrRecordID, rm *ptrRecordResourceModel) error {
+	// optional args
+	if id.view != "" {
+		rm.View = types.StringValue(id.view)
+	} else {
+		rm.View = types.StringNull()
+	}
+	// optional, computed
+	rm.Layer3domain = types.StringValue(id.layer3domain)
+	// required args
+	// keep the address as it is in the state, if it differs only in representation
+	if ip, err := netip.ParseAddr(rm.Ip.ValueString()); err != nil || ip.String() != id.ip {
+		rm.Ip = types.StringValue(id.ip)
+	}
+	rm.Ptrdname = types.StringValue(id.ptrdname)
+	// computed
+	rm.Name = types.StringValue(id.getFqdn())
+	return nil
+}
+
+// readInDimResponse parse the DIM response into the Resource Model,
+// the attributes missing in the response are left intact
+func (r ptrRecordResource) readInDimResponse(dimResp *dim.RRAttrs, rm *ptrRecordResourceModel) {
+	if v := dimResp.Created; v != "" {
+		rm.Created = types.StringValue(v)
+	}
+	if v := dimResp.CreatedBy; v != "" {
+		rm.CreatedBy = types.StringValue(v)
+	}
+	if v := dimResp.Modified; v != "" {
+		rm.Modified = types.StringValue(v)
+	}
+	if v := dimResp.ModifiedBy; v != "" {
+		rm.ModifiedBy = types.StringValue(v)
+	}
+	if v := dimResp.RR; v != "" {
+		rm.RR = types.StringValue(v)
+	}
+
+	if v := dimResp.Zone; v != "" {
+		rm.Zone = types.StringValue(v)
+	}
+
+	// read other OPTIONAl attributes
+	if dimResp.TTL != nil {
+		rm.TTL = types.Int64Value(*dimResp.TTL)
+	}
+	if dimResp.Comment != nil {
+		rm.Comment = types.StringValue(*dimResp.Comment)
+	}
+
+	// not confirmed, whether this attributes are returned
+	if dimResp.View != nil {
+		rm.View = types.StringValue(*dimResp.View)
+	}
+}
+
+func (r *ptrRecordResource) diagErrorSummaryTemplate() string {
+	return "Error in %s PTR record"
+}
+
+func (r *ptrRecordResource) diagErrorDetailTemplate() string {
+	return "Unexpected error from %s: %s"
+}
+
+// diagDimError adds the error returned by the DIM function dfunc to diags
+func (r *ptrRecordResource) diagDimError(diags *diag.Diagnostics, tfAction string, dfunc string, err error) {
+	diags.AddError(
+		fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
+		fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
+	)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ptrRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *ptrRecordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ptr_record"
+}
+
+// Schema defines the schema for the resource.
+func (r *ptrRecordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a PTR type record in DIM.\n" +
+			"The reverse zone and the layer3domain are determined from the `ip`, which must be already allocated (`status` = `Static`).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							// the address changed only in representation, e.g. 2001:db8::1 -> 2001:0db8::1
+							resp.RequiresReplace = !sameAddress(req.StateValue.ValueString(), req.PlanValue.ValueString())
+						},
+						"If the value of this attribute changes (other than in representation), Terraform will destroy and recreate the resource.",
+						"If the value of this attribute changes (other than in representation), Terraform will destroy and recreate the resource.",
+					),
+				},
+				Validators: []validator.String{
+					ipAddressValidator{},
+				},
+				MarkdownDescription: "IPv4 or IPv6 address the record points from, the equivalent representations (e.g. `2001:db8::1` and `2001:0db8::1`) are considered equal",
+			},
+			"ptrdname": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`\.$`), "must be a fqdn with trailing dot"),
+				},
+				MarkdownDescription: "the fqdn (with trailing dot) the record points to",
+			},
+			"view": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"layer3domain": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the layer 3 domain of the `ip`, determined automatically if not specified",
+			},
+
+			"comment": schema.StringAttribute{
+				Optional: true,
+			},
+			"ttl": schema.Int64Attribute{
+				Optional: true,
+			},
+
+			"name": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the reverse mapping fqdn of the `ip`",
+			},
+			"zone": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the reverse zone of the `ip`",
+			},
+			"created": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_by": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified": schema.StringAttribute{
+				Computed: true,
+			},
+			"modified_by": schema.StringAttribute{
+				Computed: true,
+			},
+			"rr": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ptrRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Create a new resource.
+	// Retrieve values from data
+	var data ptrRecordResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := newPtrRecordIDFromTfModel(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Create"),
+			fmt.Sprintf("Unable to compose ID from Resource Model: %s", err.Error()),
+		)
+		return
+	}
+
+	// Like for A records, rr_create would allocate (set to Static) the specified IP address,
+	// which would not be tracked by Terraform, so we check the IP is already allocated.
+	// The same call gives us the layer3domain of the IP (if it was not specified)
+	// and its reverse zone.
+	ipAttrs, err := r.client.IPBlockGetAttrs(ctx,
+		id.ip,
+		dim.IPBlockOptions{
+			Host:         true,
+			Layer3domain: id.layer3domain,
+		},
+	)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "ipblock_get_attrs", err)
+		return
+	}
+
+	if ipAttrs.Status != "Static" {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Create"),
+			fmt.Sprintf("IP address %s is not allocated (not marked as Static)", id.ip),
+		)
+		return
+	}
+	//now we know that the IP is allocated
+	id.layer3domain = ipAttrs.Layer3domain
+	data.Layer3domain = types.StringValue(id.layer3domain)
+	data.Zone = types.StringValue(ipAttrs.ReverseZone)
+	data.Name = types.StringValue(id.getFqdn())
+
+	// required args
+	// rr_create derives the name and the zone of the record from the ip
+	dimRR := dim.RR{
+		Type:         "PTR",
+		IP:           id.ip,
+		PTRDName:     id.ptrdname,
+		Layer3domain: id.layer3domain,
+	}
+	// optional args
+	// see https://developer.hashicorp.com/terraform/plugin/framework/handling-data/accessing-values#when-can-a-value-be-unknown-or-null
+
+	// optional
+	if !(data.View.IsNull() || data.View.IsUnknown()) {
+		dimRR.View = id.view
+	}
+	createOpts := dim.RRCreateOptions{}
+	// optional
+	if !(data.Comment.IsNull() || data.Comment.IsUnknown()) {
+		createOpts.Comment = data.Comment.ValueStringPointer()
+	}
+	// optional
+	if !(data.TTL.IsNull() || data.TTL.IsUnknown()) {
+		createOpts.TTL = data.TTL.ValueInt64Pointer()
+	}
+
+	err = r.client.RRCreate(ctx, dimRR, createOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "rr_create", err)
+		return
+	}
+	tflog.Info(ctx, "RR has been created", map[string]any{"id": id.String()})
+
+	//rr_get_attrs
+	dimRR.IP = ""
+	dimRR.Name = id.getFqdn()
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "rr_get_attrs", err)
+		return
+	}
+	r.readInDimResponse(dimResp, &data)
+
+	// now when we know the all values, set the ID
+	data.ID = types.StringValue(id.String())
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ptrRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current data
+	var data ptrRecordResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := newPtrRecordIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Read"),
+			err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("ID parsed %+v", id))
+
+	// the layer3domain might be omitted in the ID on import,
+	// it's determined from the ip like in Create
+	if id.layer3domain == "" {
+		ipAttrs, err := r.client.IPBlockGetAttrs(ctx, id.ip, dim.IPBlockOptions{Host: true})
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Read", "ipblock_get_attrs", err)
+			return
+		}
+		id.layer3domain = ipAttrs.Layer3domain
+		data.ID = types.StringValue(id.String())
+	}
+	r.restoreIDAttributesToModel(ctx, *id, &data)
+
+	// required args
+	dimRR := dim.RR{
+		Type:     "PTR",
+		Name:     id.getFqdn(),
+		PTRDName: id.ptrdname,
+	}
+	// optional args
+	// we do not check if !data.View.IsNull(), because the state might be null
+	// , e.g, after import
+	if id.view != "" {
+		dimRR.View = id.view
+	}
+	if id.layer3domain != "" {
+		dimRR.Layer3domain = id.layer3domain
+	}
+
+	tflog.Info(ctx, "Will read RR", map[string]any{"id": id.String()})
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
+	if err != nil {
+		if _, ok := err.(dim.Error); ok {
+			if err.(dim.Error).Code == 1 {
+				tflog.Debug(ctx, fmt.Sprintf("record not found (has been removed?) %+v", id))
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		r.diagDimError(&resp.Diagnostics, "Read", "rr_get_attrs", err)
+		return
+	}
+	r.readInDimResponse(dimResp, &data)
+	tflog.Info(ctx, "RR has been read", map[string]any{"id": id.String()})
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *ptrRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// only TTL and comment are updatable
+	// other args result in resource replacement
+
+	// Retrieve values from data
+	var data ptrRecordResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// required args
+	id, err := newPtrRecordIDFromTfModel(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Update"),
+			fmt.Sprintf("Unable to compose ID from Resource Model: %s", err.Error()),
+		)
+		return
+	}
+	dimRR := dim.RR{
+		Type:         "PTR",
+		Name:         id.getFqdn(),
+		PTRDName:     id.ptrdname,
+		Layer3domain: id.layer3domain,
+	}
+	// optional args
+	if !data.View.IsNull() {
+		dimRR.View = id.view
+	}
+	//updatable args
+	setOpts := dim.RRSetAttrsOptions{}
+	if !data.TTL.IsNull() {
+		setOpts.TTL = data.TTL.ValueInt64Pointer()
+	}
+	if !data.Comment.IsNull() {
+		setOpts.Comment = data.Comment.ValueStringPointer()
+	}
+
+	tflog.Info(ctx, "Will update RR", map[string]any{"id": id.String()})
+	err = r.client.RRSetAttrs(ctx, dimRR, setOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "rr_set_attrs", err)
+		return
+	}
+	tflog.Info(ctx, "RR has been updated", map[string]any{"id": id.String()})
+
+	// Read the updated attrs
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "rr_get_attrs", err)
+		return
+	}
+	r.readInDimResponse(dimResp, &data)
+
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *ptrRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from data
+	var data ptrRecordResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := newPtrRecordIDFromTfModel(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Delete"),
+			fmt.Sprintf("Unable to compose ID from Resource Model: %s", err.Error()),
+		)
+		return
+	}
+
+	// required args
+	dimRR := dim.RR{
+		Type:         "PTR",
+		Name:         id.getFqdn(),
+		PTRDName:     id.ptrdname,
+		Layer3domain: id.layer3domain,
+	}
+	// optional args
+	if id.view != "" {
+		dimRR.View = id.view
+	}
+
+	err = r.client.RRDelete(ctx, dimRR, dim.RRDeleteOptions{References: "warn"})
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Delete", "rr_delete", err)
+		return
+	}
+}
+
+func (r *ptrRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
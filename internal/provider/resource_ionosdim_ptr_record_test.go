@@ -0,0 +1,23 @@
+package provider
+
+import "testing"
+
+func TestPtrRecordIDGetFqdn(t *testing.T) {
+	tests := []struct {
+		input string
+		wants string
+	}{
+		{input: "10.88.8.11", wants: "11.8.88.10.in-addr.arpa."},
+		{input: "2001:0db8:0::1", wants: "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa."},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		id, err := newPtrRecordIDFromString("//" + tests[i].input + "/some-host.example.com.")
+		if err != nil {
+			t.Fatalf("newPtrRecordIDFromString(%q) error: %s", tests[i].input, err)
+		}
+		if got := id.getFqdn(); got != tests[i].wants {
+			t.Errorf("getFqdn() for %q = %q ; wants = %q", tests[i].input, got, tests[i].wants)
+		}
+	}
+}
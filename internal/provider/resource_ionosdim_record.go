@@ -0,0 +1,597 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &recordResource{}
+	_ resource.ResourceWithConfigure   = &recordResource{}
+	_ resource.ResourceWithImportState = &recordResource{}
+)
+
+func NewRecordResource() resource.Resource {
+	return &recordResource{}
+}
+
+type recordResource struct {
+	client *dim.Client
+}
+
+type recordResourceModel struct {
+	// common identifying RR attributes
+	ID           types.String `tfsdk:"id"`
+	Type         types.String `tfsdk:"type"`
+	Name         types.String `tfsdk:"name"`
+	Zone         types.String `tfsdk:"zone"`
+	View         types.String `tfsdk:"view"` // DIM api function rr_create has "views" plural, allowing to specify multiple view at one call. We stick to 1:1 terraform:real_world mapping
+	Layer3domain types.String `tfsdk:"layer3domain"`
+	// type specific identifying attributes
+	Fields types.Map `tfsdk:"fields"`
+	// common non-identifying changeable attributes
+	Comment types.String `tfsdk:"comment"`
+	TTL     types.Int64  `tfsdk:"ttl"`
+	// common computed attributes
+	Created    types.String `tfsdk:"created"`
+	CreatedBy  types.String `tfsdk:"created_by"`
+	Modified   types.String `tfsdk:"modified"`
+	ModifiedBy types.String `tfsdk:"modified_by"`
+	RR         types.String `tfsdk:"rr"`
+}
+
+type recordID struct {
+	zone         string
+	view         string
+	name         string
+	layer3domain string
+	rrType       string
+	fields       map[string]string
+}
+
+func newRecordIDFromString(s string) (*recordID, error) {
+	idParts := strings.SplitN(s, "/", 6)
+	if len(idParts) != 6 {
+		return nil, fmt.Errorf("ID is not in expected format")
+	}
+	// parse "fields" part of the ID
+	values, err := url.ParseQuery(idParts[5])
+	if err != nil {
+		return nil, fmt.Errorf("ID is not in expected format, fields part is not URL encoded")
+	}
+	fields := make(map[string]string, len(values))
+	for k := range values {
+		fields[k] = values.Get(k)
+	}
+	return &recordID{
+		zone:         idParts[0],
+		view:         idParts[1],
+		name:         idParts[2],
+		layer3domain: idParts[3],
+		rrType:       idParts[4],
+		fields:       fields,
+	}, nil
+}
+
+func newRecordIDFromTfModel(ctx context.Context, m recordResourceModel) (*recordID, error) {
+	newID := recordID{
+		zone:         m.Zone.ValueString(),
+		view:         m.View.ValueString(),
+		name:         m.Name.ValueString(),
+		layer3domain: m.Layer3domain.ValueString(),
+		rrType:       m.Type.ValueString(),
+	}
+	fields := map[string]string{}
+	diags := m.Fields.ElementsAs(ctx, &fields, false)
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s (%s)", diags.Errors()[0].Summary(), diags.Errors()[0].Detail())
+	}
+	newID.fields = fields
+	return &newID, nil
+}
+
+func (id recordID) String() string {
+	// <zone>/<view>/<name>/<layer3domain>/<type>/<fields>
+	// where fields are URL encoded key=value pairs sorted by key
+	values := url.Values{}
+	for k, v := range id.fields {
+		values.Set(k, v)
+	}
+	return fmt.Sprintf("%s/%s/%s/%s/%s/%s", id.zone, id.view, id.name, id.layer3domain, id.rrType, values.Encode())
+}
+
+func (id recordID) getFqdn() string {
+	if name := id.name; strings.HasSuffix(name, ".") {
+		return name
+	} else {
+		return fmt.Sprintf("%s.%s.", name, id.zone)
+	}
+}
+
+// dimFields converts the fields to the form accepted by dim.RR
+func (id recordID) dimFields() map[string]any {
+	fields := make(map[string]any, len(id.fields))
+	for k, v := range id.fields {
+		fields[k] = v
+	}
+	return fields
+}
+
+// copyIDToModel set attributes in the model to values
+// from which the ID was composed
+func (r recordResource) restoreIDAttributesToModel(ctx context.Context, id recordID, rm *recordResourceModel) error {
+	// optional args
+	if id.zone != "" {
+		rm.Zone = types.StringValue(id.zone)
+	} else {
+		rm.Zone = types.StringNull()
+	}
+	if id.view != "" {
+		rm.View = types.StringValue(id.view)
+	} else {
+		rm.View = types.StringNull()
+	}
+	if id.layer3domain != "" {
+		rm.Layer3domain = types.StringValue(id.layer3domain)
+	} else {
+		rm.Layer3domain = types.StringNull()
+	}
+	// required args
+	rm.Name = types.StringValue(id.name)
+	rm.Type = types.StringValue(id.rrType)
+	var diags diag.Diagnostics
+	rm.Fields, diags = types.MapValueFrom(ctx, types.StringType, id.fields)
+	if diags.HasError() {
+		return fmt.Errorf("error parsing fields from ID, the first error: %s (%s)", diags.Errors()[0].Summary(), diags.Errors()[0].Detail())
+	}
+	return nil
+}
+
+// readInDimResponse parse the DIM response into the Resource Model,
+// the attributes missing in the response are left intact
+func (r recordResource) readInDimResponse(dimResp *dim.RRAttrs, rm *recordResourceModel) {
+	if v := dimResp.Created; v != "" {
+		rm.Created = types.StringValue(v)
+	}
+	if v := dimResp.CreatedBy; v != "" {
+		rm.CreatedBy = types.StringValue(v)
+	}
+	if v := dimResp.Modified; v != "" {
+		rm.Modified = types.StringValue(v)
+	}
+	if v := dimResp.ModifiedBy; v != "" {
+		rm.ModifiedBy = types.StringValue(v)
+	}
+	if v := dimResp.RR; v != "" {
+		rm.RR = types.StringValue(v)
+	}
+
+	if v := dimResp.Zone; v != "" {
+		rm.Zone = types.StringValue(v)
+	}
+
+	// read other OPTIONAl attributes
+	if dimResp.TTL != nil {
+		rm.TTL = types.Int64Value(*dimResp.TTL)
+	}
+	if dimResp.Comment != nil {
+		rm.Comment = types.StringValue(*dimResp.Comment)
+	}
+
+	// not confirmed, whether this attributes are returned
+	if dimResp.View != nil {
+		rm.View = types.StringValue(*dimResp.View)
+	}
+}
+
+func (r *recordResource) diagErrorSummaryTemplate() string {
+	return "Error in %s record"
+}
+
+func (r *recordResource) diagErrorDetailTemplate() string {
+	return "Unexpected error from %s: %s"
+}
+
+// diagDimError adds the error returned by the DIM function dfunc to diags
+func (r *recordResource) diagDimError(diags *diag.Diagnostics, tfAction string, dfunc string, err error) {
+	diags.AddError(
+		fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
+		fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
+	)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *recordResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *recordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record"
+}
+
+// Schema defines the schema for the resource.
+func (r *recordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a record of any type in DIM, the type specific fields are passed to `rr_create` as they are.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Z][A-Z0-9]*$`), "must be an upper case RR type, e.g. SSHFP"),
+				},
+				MarkdownDescription: "the type of the RR, e.g. `SSHFP`, `TLSA`, `CAA`, `NAPTR`",
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "the fqdn of the RR or the relative name if zone was specified",
+			},
+			"zone": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "optional if name is a fqdn",
+			},
+			"view": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"layer3domain": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "needed only for the types referring an ip address",
+			},
+
+			"fields": schema.MapAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "the type specific fields of the RR as accepted by DIM `rr_create`",
+			},
+
+			"comment": schema.StringAttribute{
+				Optional: true,
+			},
+			"ttl": schema.Int64Attribute{
+				Optional: true,
+			},
+
+			"created": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_by": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified": schema.StringAttribute{
+				Computed: true,
+			},
+			"modified_by": schema.StringAttribute{
+				Computed: true,
+			},
+			"rr": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Create a new resource.
+	// Retrieve values from data
+	var data recordResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// required args
+	id, err := newRecordIDFromTfModel(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Create"),
+			fmt.Sprintf("Unable to compose ID from Resource Model: %s", err.Error()),
+		)
+		return
+	}
+
+	dimRR := dim.RR{
+		Type:   id.rrType,
+		Name:   id.name,
+		Fields: id.dimFields(),
+	}
+	// optional args
+	// see https://developer.hashicorp.com/terraform/plugin/framework/handling-data/accessing-values#when-can-a-value-be-unknown-or-null
+
+	// optional, computed
+	if !(data.Zone.IsNull() || data.Zone.IsUnknown()) {
+		dimRR.Zone = id.zone
+	}
+	// optional
+	if !(data.View.IsNull() || data.View.IsUnknown()) {
+		dimRR.View = id.view
+	}
+	// optional
+	if !(data.Layer3domain.IsNull() || data.Layer3domain.IsUnknown()) {
+		dimRR.Layer3domain = id.layer3domain
+	}
+	createOpts := dim.RRCreateOptions{}
+	// optional
+	if !(data.Comment.IsNull() || data.Comment.IsUnknown()) {
+		createOpts.Comment = data.Comment.ValueStringPointer()
+	}
+	// optional
+	if !(data.TTL.IsNull() || data.TTL.IsUnknown()) {
+		createOpts.TTL = data.TTL.ValueInt64Pointer()
+	}
+
+	err = r.client.RRCreate(ctx, dimRR, createOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "rr_create", err)
+		return
+	}
+	tflog.Info(ctx, "RR has been created", map[string]any{"id": id.String()})
+
+	//rr_get_attrs
+	// rr_get_attrs accepts the subset of the args of rr_create
+	dimRR.Zone = ""
+	dimRR.Name = id.getFqdn() // rr_get_attrs has no "zone" arg, so "name" arg must be fqdn
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "rr_get_attrs", err)
+		return
+	}
+	r.readInDimResponse(dimResp, &data)
+
+	// now when we know the all values, set the ID
+	data.ID = types.StringValue(id.String())
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *recordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current data
+	var data recordResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := newRecordIDFromString(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Read"),
+			err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("ID parsed %+v", id))
+	if err := r.restoreIDAttributesToModel(ctx, *id, &data); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Read"),
+			err.Error(),
+		)
+		return
+	}
+
+	// required args
+	dimRR := dim.RR{
+		Type:   id.rrType,
+		Name:   id.getFqdn(), // rr_get_attrs accepts no "zone" arg, so "name" must be fqdn with trailing dot
+		Fields: id.dimFields(),
+	}
+	// optional args
+	// we do not check if !data.View.IsNull(), because the state might be null
+	// , e.g, after import
+	if id.view != "" {
+		dimRR.View = id.view
+	}
+	if id.layer3domain != "" {
+		dimRR.Layer3domain = id.layer3domain
+	}
+
+	tflog.Info(ctx, "Will read RR", map[string]any{"id": id.String()})
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
+	if err != nil {
+		if _, ok := err.(dim.Error); ok {
+			if err.(dim.Error).Code == 1 {
+				tflog.Debug(ctx, fmt.Sprintf("record not found (has been removed?) %+v", id))
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		r.diagDimError(&resp.Diagnostics, "Read", "rr_get_attrs", err)
+		return
+	}
+	r.readInDimResponse(dimResp, &data)
+	tflog.Info(ctx, "RR has been read", map[string]any{"id": id.String()})
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *recordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// only TTL and comment are updatable
+	// other args result in resource replacement
+
+	// Retrieve values from data
+	var data recordResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// required args
+	id, err := newRecordIDFromTfModel(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Update"),
+			fmt.Sprintf("Unable to compose ID from Resource Model: %s", err.Error()),
+		)
+		return
+	}
+	dimRR := dim.RR{
+		Type:   id.rrType,
+		Name:   id.getFqdn(), // rr_set_attrs has no "zone" attr, so "name" must be fqdn with trailing dot
+		Fields: id.dimFields(),
+	}
+	// optional args
+	if !data.View.IsNull() {
+		dimRR.View = id.view
+	}
+	if !data.Layer3domain.IsNull() {
+		dimRR.Layer3domain = id.layer3domain
+	}
+	//updatable args
+	setOpts := dim.RRSetAttrsOptions{}
+	if !data.TTL.IsNull() {
+		setOpts.TTL = data.TTL.ValueInt64Pointer()
+	}
+	if !data.Comment.IsNull() {
+		setOpts.Comment = data.Comment.ValueStringPointer()
+	}
+
+	tflog.Info(ctx, "Will update RR", map[string]any{"id": id.String()})
+	err = r.client.RRSetAttrs(ctx, dimRR, setOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "rr_set_attrs", err)
+		return
+	}
+	tflog.Info(ctx, "RR has been updated", map[string]any{"id": id.String()})
+
+	// Read the updated attrs
+	dimResp, err := r.client.RRGetAttrs(ctx, dimRR)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Update", "rr_get_attrs", err)
+		return
+	}
+	r.readInDimResponse(dimResp, &data)
+
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *recordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from data
+	var data recordResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := newRecordIDFromTfModel(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Delete"),
+			fmt.Sprintf("Unable to compose ID from Resource Model: %s", err.Error()),
+		)
+		return
+	}
+
+	// required args
+	dimRR := dim.RR{
+		Type:   id.rrType,
+		Name:   id.name,
+		Fields: id.dimFields(),
+	}
+	// optional args
+	if id.zone != "" {
+		dimRR.Zone = id.zone
+	}
+	if id.view != "" {
+		dimRR.View = id.view
+	}
+	if id.layer3domain != "" {
+		dimRR.Layer3domain = id.layer3domain
+	}
+
+	err = r.client.RRDelete(ctx, dimRR, dim.RRDeleteOptions{References: "warn"})
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Delete", "rr_delete", err)
+		return
+	}
+}
+
+func (r *recordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
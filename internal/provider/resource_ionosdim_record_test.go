@@ -0,0 +1,20 @@
+package provider
+
+import "testing"
+
+func TestRecordIDRoundTrip(t *testing.T) {
+	s := "example.com//host-01//SSHFP/algorithm=4&fingerprint=abcd&fingerprint_type=2"
+	id, err := newRecordIDFromString(s)
+	if err != nil {
+		t.Fatalf("newRecordIDFromString(%q) error: %s", s, err)
+	}
+	if got := id.fields["fingerprint_type"]; got != "2" {
+		t.Errorf("newRecordIDFromString(%q).fields[fingerprint_type] = %q ; wants = %q", s, got, "2")
+	}
+	if got := id.String(); got != s {
+		t.Errorf("newRecordIDFromString(%q).String() = %q", s, got)
+	}
+	if _, err := newRecordIDFromString("example.com//host-01/SSHFP"); err == nil {
+		t.Errorf("newRecordIDFromString() accepted an ID with missing parts")
+	}
+}
@@ -0,0 +1,37 @@
+package provider
+
+import "testing"
+
+func TestSrvRecordNameRegexp(t *testing.T) {
+	tests := []struct {
+		input string
+		wants bool
+	}{
+		{input: "_ldap._tcp", wants: true},
+		{input: "_kerberos._udp.example.com.", wants: true},
+		{input: "_sip._tls.voice", wants: true},
+		{input: "ldap._tcp", wants: false},
+		{input: "_ldap.tcp.example.com.", wants: false},
+		{input: "_ldap", wants: false},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		if got := srvRecordNameRegexp.MatchString(tests[i].input); got != tests[i].wants {
+			t.Errorf("srvRecordNameRegexp.MatchString(%q) = %t ; wants = %t", tests[i].input, got, tests[i].wants)
+		}
+	}
+}
+
+func TestSrvRecordIDRoundTrip(t *testing.T) {
+	s := "example.com//_ldap._tcp/10/5/389/ldap-01.example.com."
+	id, err := newSrvRecordIDFromString(s)
+	if err != nil {
+		t.Fatalf("newSrvRecordIDFromString(%q) error: %s", s, err)
+	}
+	if got := id.String(); got != s {
+		t.Errorf("newSrvRecordIDFromString(%q).String() = %q", s, got)
+	}
+	if _, err := newSrvRecordIDFromString("example.com//_ldap._tcp/10/x/389/ldap-01.example.com."); err == nil {
+		t.Errorf("newSrvRecordIDFromString() accepted not a number weight")
+	}
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccTXTRecordResource(t *testing.T) {
+	s := testAccDIM(t)
+	c := testAccDIMClient(t, s)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckNoRecords(c, "TXT"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccTXTRecordResourceConfig("v=spf1 -all", 300, "some comment"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ionosdim_txt_record.test", "id", "example.com//txt/v%3Dspf1+-all,second+string"),
+					resource.TestCheckResourceAttr("ionosdim_txt_record.test", "strings.#", "2"),
+					resource.TestCheckResourceAttr("ionosdim_txt_record.test", "ttl", "300"),
+					resource.TestCheckResourceAttr("ionosdim_txt_record.test", "comment", "some comment"),
+					resource.TestCheckResourceAttr("ionosdim_txt_record.test", "rr", `txt TXT "v=spf1 -all" "second string"`),
+				),
+			},
+			// Update in place testing
+			{
+				Config: testAccTXTRecordResourceConfig("v=spf1 -all", 600, "other comment"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ionosdim_txt_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ionosdim_txt_record.test", "ttl", "600"),
+					resource.TestCheckResourceAttr("ionosdim_txt_record.test", "comment", "other comment"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ionosdim_txt_record.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Replace testing
+			{
+				Config: testAccTXTRecordResourceConfig("v=spf1 mx -all", 600, "other comment"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ionosdim_txt_record.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("ionosdim_txt_record.test", "rr", `txt TXT "v=spf1 mx -all" "second string"`),
+			},
+			// Drift testing, the deleted record is created again
+			{
+				PreConfig: func() {
+					rr := dim.RR{Type: "TXT", Name: "txt", Zone: testAccZone, Strings: []string{"v=spf1 mx -all", "second string"}}
+					if err := c.RRDelete(context.Background(), rr, dim.RRDeleteOptions{}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccTXTRecordResourceConfig("v=spf1 mx -all", 600, "other comment"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ionosdim_txt_record.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("ionosdim_txt_record.test", "rr", `txt TXT "v=spf1 mx -all" "second string"`),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccTXTRecordResourceConfig(first string, ttl int, comment string) string {
+	return fmt.Sprintf(`
+resource "ionosdim_txt_record" "test" {
+  name    = "txt"
+  zone    = %[1]q
+  strings = [%[2]q, "second string"]
+  ttl     = %[3]d
+  comment = %[4]q
+}
+`, testAccZone, first, ttl, comment)
+}
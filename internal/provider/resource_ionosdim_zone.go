@@ -0,0 +1,614 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &zoneResource{}
+	_ resource.ResourceWithConfigure   = &zoneResource{}
+	_ resource.ResourceWithImportState = &zoneResource{}
+	_ resource.ResourceWithModifyPlan  = &zoneResource{}
+)
+
+func NewZoneResource() resource.Resource {
+	return &zoneResource{}
+}
+
+type zoneResource struct {
+	client *dim.Client
+}
+
+type zoneResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	FromProfile types.String `tfsdk:"from_profile"`
+	View        types.String `tfsdk:"view"`
+	// SOA attributes, changeable in place
+	Primary types.String `tfsdk:"primary"`
+	Mail    types.String `tfsdk:"mail"`
+	Refresh types.Int64  `tfsdk:"refresh"`
+	Retry   types.Int64  `tfsdk:"retry"`
+	Expire  types.Int64  `tfsdk:"expire"`
+	Minimum types.Int64  `tfsdk:"minimum"`
+	TTL     types.Int64  `tfsdk:"ttl"`
+	Serial  types.Int64  `tfsdk:"serial"`
+
+	Comment types.String `tfsdk:"comment"`
+
+	Created    types.String `tfsdk:"created"`
+	CreatedBy  types.String `tfsdk:"created_by"`
+	Modified   types.String `tfsdk:"modified"`
+	ModifiedBy types.String `tfsdk:"modified_by"`
+}
+
+// soaAttrs returns the SOA attributes known in the model
+func (rm zoneResourceModel) soaAttrs() dim.ZoneSOAAttrs {
+	soa := dim.ZoneSOAAttrs{}
+	if !(rm.Primary.IsNull() || rm.Primary.IsUnknown()) {
+		soa.Primary = rm.Primary.ValueString()
+	}
+	if !(rm.Mail.IsNull() || rm.Mail.IsUnknown()) {
+		soa.Mail = rm.Mail.ValueString()
+	}
+	if !(rm.Refresh.IsNull() || rm.Refresh.IsUnknown()) {
+		soa.Refresh = rm.Refresh.ValueInt64Pointer()
+	}
+	if !(rm.Retry.IsNull() || rm.Retry.IsUnknown()) {
+		soa.Retry = rm.Retry.ValueInt64Pointer()
+	}
+	if !(rm.Expire.IsNull() || rm.Expire.IsUnknown()) {
+		soa.Expire = rm.Expire.ValueInt64Pointer()
+	}
+	if !(rm.Minimum.IsNull() || rm.Minimum.IsUnknown()) {
+		soa.Minimum = rm.Minimum.ValueInt64Pointer()
+	}
+	if !(rm.TTL.IsNull() || rm.TTL.IsUnknown()) {
+		soa.TTL = rm.TTL.ValueInt64Pointer()
+	}
+	return soa
+}
+
+// soaChanged reports whether any of the SOA attributes differs from the state
+func (rm zoneResourceModel) soaChanged(state zoneResourceModel) bool {
+	return !rm.Primary.Equal(state.Primary) ||
+		!rm.Mail.Equal(state.Mail) ||
+		!rm.Refresh.Equal(state.Refresh) ||
+		!rm.Retry.Equal(state.Retry) ||
+		!rm.Expire.Equal(state.Expire) ||
+		!rm.Minimum.Equal(state.Minimum) ||
+		!rm.TTL.Equal(state.TTL)
+}
+
+// readInDimResponse parse the DIM responses into the Resource Model,
+// the attributes missing in the responses are left intact
+func (rm *zoneResourceModel) readInDimResponse(dimResp *dim.ZoneAttrs, dimSOAResp *dim.ZoneSOAAttrs) {
+	if v := dimResp.Name; v != "" {
+		rm.Name = types.StringValue(v)
+	}
+	if v := dimResp.Created; v != "" {
+		rm.Created = types.StringValue(v)
+	}
+	if v := dimResp.CreatedBy; v != "" {
+		rm.CreatedBy = types.StringValue(v)
+	}
+	if v := dimResp.Modified; v != "" {
+		rm.Modified = types.StringValue(v)
+	}
+	if v := dimResp.ModifiedBy; v != "" {
+		rm.ModifiedBy = types.StringValue(v)
+	}
+	if dimResp.Comment != nil {
+		rm.Comment = types.StringValue(*dimResp.Comment)
+	}
+
+	if v := dimSOAResp.Primary; v != "" {
+		rm.Primary = types.StringValue(v)
+	}
+	if v := dimSOAResp.Mail; v != "" {
+		rm.Mail = types.StringValue(v)
+	}
+	if v := dimSOAResp.Refresh; v != nil {
+		rm.Refresh = types.Int64Value(*v)
+	}
+	if v := dimSOAResp.Retry; v != nil {
+		rm.Retry = types.Int64Value(*v)
+	}
+	if v := dimSOAResp.Expire; v != nil {
+		rm.Expire = types.Int64Value(*v)
+	}
+	if v := dimSOAResp.Minimum; v != nil {
+		rm.Minimum = types.Int64Value(*v)
+	}
+	if v := dimSOAResp.TTL; v != nil {
+		rm.TTL = types.Int64Value(*v)
+	}
+	if v := dimSOAResp.Serial; v != nil {
+		rm.Serial = types.Int64Value(*v)
+	}
+}
+
+func (r *zoneResource) diagErrorSummaryTemplate() string {
+	return "Error in %s zone"
+}
+
+func (r *zoneResource) diagErrorDetailTemplate() string {
+	return "Unexpected error from %s: %s"
+}
+
+// diagDimError adds the error returned by the DIM function dfunc to diags
+func (r *zoneResource) diagDimError(diags *diag.Diagnostics, tfAction string, dfunc string, err error) {
+	diags.AddError(
+		fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
+		fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
+	)
+}
+
+// resolveView sets the view of the SOA attributes in the model
+// to the only view of the zone unless it is known already
+func (r *zoneResource) resolveView(ctx context.Context, diags *diag.Diagnostics, tfAction string, name string, rm *zoneResourceModel) {
+	if !(rm.View.IsNull() || rm.View.IsUnknown()) {
+		return
+	}
+	dimViews, err := r.client.ZoneListViews(ctx, name)
+	if err != nil {
+		r.diagDimError(diags, tfAction, "zone_list_views", err)
+		return
+	}
+	if len(dimViews) != 1 {
+		diags.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
+			fmt.Sprintf("The zone %s has %d views, the view attribute must be set.", name, len(dimViews)),
+		)
+		return
+	}
+	rm.View = types.StringValue(dimViews[0].Name)
+}
+
+// createView makes the view of the SOA attributes exist in the newly created zone
+// and sets its SOA attributes. The single view of the new zone is renamed to the view,
+// a zone created from a profile with several views must have the view already.
+func (r *zoneResource) createView(ctx context.Context, diags *diag.Diagnostics, name, view string, soa dim.ZoneSOAAttrs) {
+	dimViews, err := r.client.ZoneListViews(ctx, name)
+	if err != nil {
+		r.diagDimError(diags, "Create", "zone_list_views", err)
+		return
+	}
+	found := false
+	for _, v := range dimViews {
+		found = found || v.Name == view
+	}
+	if !found {
+		if len(dimViews) != 1 {
+			diags.AddError(
+				fmt.Sprintf(r.diagErrorSummaryTemplate(), "Create"),
+				fmt.Sprintf("The zone %s was created with %d views, none of them is the view %s.", name, len(dimViews), view),
+			)
+			return
+		}
+		if err := r.client.ZoneRenameView(ctx, name, dimViews[0].Name, view); err != nil {
+			r.diagDimError(diags, "Create", "zone_rename_view", err)
+			return
+		}
+		tflog.Info(ctx, "Zone view has been renamed", map[string]any{"name": name, "from": dimViews[0].Name, "view": view})
+	}
+	if soa != (dim.ZoneSOAAttrs{}) {
+		if err := r.client.ZoneSetSOAAttrs(ctx, name, soa, dim.ZoneViewOptions{View: view}); err != nil {
+			r.diagDimError(diags, "Create", "zone_set_soa_attrs", err)
+		}
+	}
+}
+
+// readZone reads the zone attributes and the SOA attributes of the view into the model
+func (r *zoneResource) readZone(ctx context.Context, diags *diag.Diagnostics, tfAction string, name string, rm *zoneResourceModel) {
+	dimResp, err := r.client.ZoneGetAttrs(ctx, name, dim.ZoneOptions{})
+	if err != nil {
+		r.diagDimError(diags, tfAction, "zone_get_attrs", err)
+		return
+	}
+	r.resolveView(ctx, diags, tfAction, name, rm)
+	if diags.HasError() {
+		return
+	}
+	dimSOAResp, err := r.client.ZoneGetSOAAttrs(ctx, name, dim.ZoneViewOptions{View: rm.View.ValueString()})
+	if err != nil {
+		r.diagDimError(diags, tfAction, "zone_get_soa_attrs", err)
+		return
+	}
+	rm.readInDimResponse(dimResp, dimSOAResp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *zoneResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *zoneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone"
+}
+
+// Schema defines the schema for the resource.
+func (r *zoneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a zone in DIM.\n" +
+			"The SOA attributes left unspecified are set by DIM (or taken from the zone profile)," +
+			" the SOA attributes of the view are updated in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "the name of the zone, e.g. `example.com`",
+			},
+			"from_profile": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					// DIM does not return the profile the zone was created from,
+					// so it's not known after import
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"If the value of this attribute changes, Terraform will destroy and recreate the resource.",
+						"If the value of this attribute changes, Terraform will destroy and recreate the resource.",
+					),
+				},
+				MarkdownDescription: "the name of the zone profile the zone is created from",
+			},
+			"view": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the view of the SOA attributes, must be set if the zone has several views;" +
+					" defaults to the only view of the zone." +
+					" On create, the single view of the new zone is renamed to it." +
+					" Changing it later does not rename the view in DIM, it only selects the view whose SOA attributes are managed.",
+			},
+
+			"primary": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the SOA primary name server",
+			},
+			"mail": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the SOA responsible mailbox",
+			},
+			"refresh": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the SOA refresh interval in seconds",
+			},
+			"retry": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the SOA retry interval in seconds",
+			},
+			"expire": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the SOA expire time in seconds",
+			},
+			"minimum": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the SOA minimum (negative caching) TTL in seconds",
+			},
+			"ttl": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "the default TTL of the records in the zone",
+			},
+			"serial": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "the SOA serial, managed by DIM",
+			},
+
+			"comment": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"created": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_by": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified": schema.StringAttribute{
+				Computed: true,
+			},
+			"modified_by": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from data
+	var data zoneResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+	createOpts := dim.ZoneCreateOptions{}
+	// optional args
+	if !(data.FromProfile.IsNull() || data.FromProfile.IsUnknown()) {
+		createOpts.FromProfile = data.FromProfile.ValueString()
+	}
+	// zone_create sets the SOA attributes of the default view,
+	// those of the configured view are set once the view exists
+	soa := data.soaAttrs()
+	hasView := !(data.View.IsNull() || data.View.IsUnknown())
+	if !hasView && soa != (dim.ZoneSOAAttrs{}) {
+		createOpts.SOAAttributes = &soa
+	}
+	if !(data.Comment.IsNull() || data.Comment.IsUnknown()) {
+		createOpts.Attributes = map[string]string{"comment": data.Comment.ValueString()}
+	}
+
+	err := r.client.ZoneCreate(ctx, name, createOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "zone_create", err)
+		return
+	}
+	tflog.Info(ctx, "Zone has been created", map[string]any{"name": name})
+
+	if hasView {
+		r.createView(ctx, &resp.Diagnostics, name, data.View.ValueString(), soa)
+		if resp.Diagnostics.HasError() {
+			// do not leave the zone behind, it is not in the state
+			if err := r.client.ZoneDelete(ctx, name, dim.ZoneDeleteOptions{Cleanup: true}); err != nil {
+				r.diagDimError(&resp.Diagnostics, "Create", "zone_delete", err)
+			}
+			return
+		}
+	}
+
+	r.readZone(ctx, &resp.Diagnostics, "Create", name, &data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(name)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current data
+	var data zoneResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.ID.ValueString()
+	data.Name = types.StringValue(name) // e.g. after import
+
+	dimResp, err := r.client.ZoneGetAttrs(ctx, name, dim.ZoneOptions{})
+	if err != nil {
+		if _, ok := err.(dim.Error); ok {
+			if err.(dim.Error).Code == 1 {
+				tflog.Debug(ctx, fmt.Sprintf("zone not found (has been removed?) %s", name))
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		r.diagDimError(&resp.Diagnostics, "Read", "zone_get_attrs", err)
+		return
+	}
+	r.resolveView(ctx, &resp.Diagnostics, "Read", name, &data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	dimSOAResp, err := r.client.ZoneGetSOAAttrs(ctx, name, dim.ZoneViewOptions{View: data.View.ValueString()})
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Read", "zone_get_soa_attrs", err)
+		return
+	}
+	data.readInDimResponse(dimResp, dimSOAResp)
+	tflog.Info(ctx, "Zone has been read", map[string]any{"name": name})
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan marks the SOA attributes not in the configuration unknown
+// if the view changes, they are read from the other view then.
+func (r *zoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan, state, config zoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.View.IsUnknown() || plan.View.Equal(state.View) {
+		return
+	}
+
+	if config.Primary.IsNull() {
+		plan.Primary = types.StringUnknown()
+	}
+	if config.Mail.IsNull() {
+		plan.Mail = types.StringUnknown()
+	}
+	if config.Refresh.IsNull() {
+		plan.Refresh = types.Int64Unknown()
+	}
+	if config.Retry.IsNull() {
+		plan.Retry = types.Int64Unknown()
+	}
+	if config.Expire.IsNull() {
+		plan.Expire = types.Int64Unknown()
+	}
+	if config.Minimum.IsNull() {
+		plan.Minimum = types.Int64Unknown()
+	}
+	if config.TTL.IsNull() {
+		plan.TTL = types.Int64Unknown()
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// the view, the SOA attributes and comment are updatable
+	// the name and from_profile result in resource replacement
+
+	var data, state zoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	r.resolveView(ctx, &resp.Diagnostics, "Update", name, &data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// only the configured SOA attributes are known if the view changes
+	if soa := data.soaAttrs(); data.soaChanged(state) && soa != (dim.ZoneSOAAttrs{}) {
+		err := r.client.ZoneSetSOAAttrs(ctx, name, soa, dim.ZoneViewOptions{View: data.View.ValueString()})
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Update", "zone_set_soa_attrs", err)
+			return
+		}
+	}
+	if !data.Comment.Equal(state.Comment) {
+		err := r.client.ZoneSetAttrs(ctx,
+			name,
+			map[string]string{
+				"comment": data.Comment.ValueString(),
+			},
+			dim.ZoneOptions{},
+		)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Update", "zone_set_attrs", err)
+			return
+		}
+	}
+	tflog.Info(ctx, "Zone has been updated", map[string]any{"name": name})
+
+	// Read the updated attrs
+	r.readZone(ctx, &resp.Diagnostics, "Update", name, &data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *zoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from data
+	var data zoneResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.ZoneDelete(ctx, data.ID.ValueString(), dim.ZoneDeleteOptions{})
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Delete", "zone_delete", err)
+		return
+	}
+}
+
+func (r *zoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
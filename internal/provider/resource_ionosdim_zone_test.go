@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-ionosdim/pkg/dim"
+	"terraform-provider-ionosdim/pkg/dim/dimtest"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccZoneResource(t *testing.T) {
+	testAccDIM(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccZoneResourceConfig("ns1.example.org.", "some comment"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ionosdim_zone.test", "id", "example.org"),
+					resource.TestCheckResourceAttr("ionosdim_zone.test", "view", "internal"),
+					resource.TestCheckResourceAttr("ionosdim_zone.test", "primary", "ns1.example.org."),
+				),
+			},
+			// Update in place testing
+			{
+				Config: testAccZoneResourceConfig("ns2.example.org.", "other comment"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ionosdim_zone.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ionosdim_zone.test", "primary", "ns2.example.org."),
+					resource.TestCheckResourceAttr("ionosdim_zone.test", "comment", "other comment"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ionosdim_zone.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccZoneResourceConfig(primary, comment string) string {
+	return fmt.Sprintf(`
+resource "ionosdim_zone" "test" {
+  name    = "example.org"
+  view    = "internal"
+  primary = %[1]q
+  comment = %[2]q
+}
+`, primary, comment)
+}
+
+func TestZoneResourceCreateView(t *testing.T) {
+	ctx := context.Background()
+	s := dimtest.NewServer()
+	t.Cleanup(s.Close)
+	c := testAccDIMClient(t, s)
+
+	plan := zoneResourceModel{
+		Name:    types.StringValue("example.org"),
+		View:    types.StringValue("internal"),
+		Primary: types.StringValue("ns1.example.org."),
+		Comment: types.StringValue("some comment"),
+	}
+	var state zoneResourceModel
+	if diags := testResourceCreate(t, NewZoneResource(), c, plan, &state); diags.HasError() {
+		t.Fatalf("Create() error: %v", diags)
+	}
+	if state.ID.ValueString() != "example.org" || state.View.ValueString() != "internal" ||
+		state.Primary.ValueString() != "ns1.example.org." || state.Comment.ValueString() != "some comment" {
+		t.Errorf("Create() state = %+v", state)
+	}
+	views, err := c.ZoneListViews(ctx, "example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(views) != 1 || views[0].Name != "internal" {
+		t.Errorf("ZoneListViews() = %+v ; wants the internal view only", views)
+	}
+	soa, err := c.ZoneGetSOAAttrs(ctx, "example.org", dim.ZoneViewOptions{View: "internal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soa.Primary != "ns1.example.org." {
+		t.Errorf("ZoneGetSOAAttrs().Primary = %q", soa.Primary)
+	}
+}
+
+func TestZoneResourceCreateDefaultView(t *testing.T) {
+	s := dimtest.NewServer()
+	t.Cleanup(s.Close)
+	c := testAccDIMClient(t, s)
+
+	plan := zoneResourceModel{
+		Name:    types.StringValue("example.org"),
+		Primary: types.StringValue("ns1.example.org."),
+	}
+	var state zoneResourceModel
+	if diags := testResourceCreate(t, NewZoneResource(), c, plan, &state); diags.HasError() {
+		t.Fatalf("Create() error: %v", diags)
+	}
+	if state.View.ValueString() != dimtest.DefaultView || state.Primary.ValueString() != "ns1.example.org." {
+		t.Errorf("Create() state = %+v", state)
+	}
+}
@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-ionosdim/pkg/dim"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &zoneViewResource{}
+	_ resource.ResourceWithConfigure   = &zoneViewResource{}
+	_ resource.ResourceWithImportState = &zoneViewResource{}
+)
+
+func NewZoneViewResource() resource.Resource {
+	return &zoneViewResource{}
+}
+
+type zoneViewResource struct {
+	client *dim.Client
+}
+
+type zoneViewResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Zone        types.String `tfsdk:"zone"`
+	Name        types.String `tfsdk:"name"`
+	FromProfile types.String `tfsdk:"from_profile"`
+}
+
+type zoneViewID struct {
+	zone string
+	name string
+}
+
+func (rm zoneViewResourceModel) composeID() string {
+	// <zone>/<name>
+	return rm.Zone.ValueString() + "/" + rm.Name.ValueString()
+}
+
+func (rm zoneViewResourceModel) parseID() (zoneViewID, error) {
+	idParts := strings.SplitN(rm.ID.ValueString(), "/", 2)
+	if len(idParts) != 2 {
+		return zoneViewID{}, fmt.Errorf("ID is not in expected format")
+	}
+	return zoneViewID{
+		zone: idParts[0],
+		name: idParts[1],
+	}, nil
+}
+
+func (r *zoneViewResource) diagErrorSummaryTemplate() string {
+	return "Error in %s zone view"
+}
+
+func (r *zoneViewResource) diagErrorDetailTemplate() string {
+	return "Unexpected error from %s: %s"
+}
+
+// diagDimError adds the error returned by the DIM function dfunc to diags
+func (r *zoneViewResource) diagDimError(diags *diag.Diagnostics, tfAction string, dfunc string, err error) {
+	diags.AddError(
+		fmt.Sprintf(r.diagErrorSummaryTemplate(), tfAction),
+		fmt.Sprintf(r.diagErrorDetailTemplate(), dfunc, err.Error()),
+	)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *zoneViewResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dim.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *dim.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *zoneViewResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_view"
+}
+
+// Schema defines the schema for the resource.
+func (r *zoneViewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a view of a zone in DIM. Changing the `name` renames the view in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true, // changes on rename, so no UseStateForUnknown
+			},
+			"zone": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "the name of the zone",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "the name of the view, e.g. `internal`",
+			},
+			"from_profile": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					// DIM does not return the profile the view was created from,
+					// so it's not known after import
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"If the value of this attribute changes, Terraform will destroy and recreate the resource.",
+						"If the value of this attribute changes, Terraform will destroy and recreate the resource.",
+					),
+				},
+				MarkdownDescription: "the name of the zone profile the view is created from",
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *zoneViewResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from data
+	var data zoneViewResourceModel
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createOpts := dim.ZoneViewCreateOptions{}
+	// optional args
+	if !(data.FromProfile.IsNull() || data.FromProfile.IsUnknown()) {
+		createOpts.FromProfile = data.FromProfile.ValueString()
+	}
+
+	err := r.client.ZoneCreateView(ctx, data.Zone.ValueString(), data.Name.ValueString(), createOpts)
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Create", "zone_create_view", err)
+		return
+	}
+	data.ID = types.StringValue(data.composeID())
+	tflog.Info(ctx, "Zone view has been created", map[string]any{"id": data.ID.ValueString()})
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *zoneViewResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current data
+	var data zoneViewResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := data.parseID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Read"),
+			err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("ID parsed %+v", id))
+	// e.g. after import
+	data.Zone = types.StringValue(id.zone)
+	data.Name = types.StringValue(id.name)
+
+	dimResp, err := r.client.ZoneListViews(ctx, id.zone)
+	if err != nil {
+		if _, ok := err.(dim.Error); ok {
+			if err.(dim.Error).Code == 1 {
+				tflog.Debug(ctx, fmt.Sprintf("zone not found (has been removed?) %+v", id))
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		r.diagDimError(&resp.Diagnostics, "Read", "zone_list_views", err)
+		return
+	}
+
+	found := false
+	for _, v := range dimResp {
+		if v.Name == id.name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		tflog.Debug(ctx, fmt.Sprintf("zone view not found (has been removed?) %+v", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	tflog.Info(ctx, "Zone view has been read", map[string]any{"id": data.ID.ValueString()})
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *zoneViewResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// only the name is updatable (the view is renamed)
+
+	var data, state zoneViewResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, _ := state.parseID() // well we know it's valid, so no need to check the error
+
+	if newName := data.Name.ValueString(); newName != id.name {
+		err := r.client.ZoneRenameView(ctx, id.zone, id.name, newName)
+		if err != nil {
+			r.diagDimError(&resp.Diagnostics, "Update", "zone_rename_view", err)
+			return
+		}
+	}
+	data.ID = types.StringValue(data.composeID())
+	tflog.Info(ctx, "Zone view has been updated", map[string]any{"id": data.ID.ValueString()})
+
+	diags := resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *zoneViewResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from data
+	var data zoneViewResourceModel
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := data.parseID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf(r.diagErrorSummaryTemplate(), "Delete"),
+			err.Error(),
+		)
+		return
+	}
+
+	err = r.client.ZoneDeleteView(ctx, id.zone, id.name, dim.ZoneViewDeleteOptions{})
+	if err != nil {
+		r.diagDimError(&resp.Diagnostics, "Delete", "zone_delete_view", err)
+		return
+	}
+}
+
+func (r *zoneViewResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
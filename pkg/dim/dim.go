@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-kit/log"
@@ -21,8 +23,17 @@ type Client struct {
 	endpoint   string
 	httpClient *http.Client
 	auth       AuthStruct
-	token      string
 	logger     log.Logger
+
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+
+	callLogger CallLogger
+
+	// mu guards token, which is replaced on login
+	mu    sync.Mutex
+	token string
 }
 
 // AuthStruct -
@@ -32,7 +43,7 @@ type AuthStruct struct {
 }
 
 type rawResponse struct {
-	Result interface{}      `json:"result"`
+	Result json.RawMessage  `json:"result"`
 	Error  rawResponseError `json:"error"`
 }
 
@@ -43,21 +54,44 @@ type rawResponseError struct {
 
 //type Response interface{}
 
-func NewClient(endpoint, token, username, password *string, logger log.Logger) (*Client, error) {
-	return NewClientWithContext(context.Background(), endpoint, token, username, password, logger)
+// ClientOption configures an optional setting of the Client
+type ClientOption func(*Client) error
+
+// CallLogger is called after each DIM function call with its arguments,
+// the raw result and the error, e.g. to log the calls for debugging
+type CallLogger func(ctx context.Context, function string, args any, result json.RawMessage, err error)
+
+// WithCallLogger sets the CallLogger of the Client
+func WithCallLogger(f CallLogger) ClientOption {
+	return func(c *Client) error {
+		c.callLogger = f
+		return nil
+	}
+}
+
+func NewClient(endpoint, token, username, password *string, logger log.Logger, opts ...ClientOption) (*Client, error) {
+	return NewClientWithContext(context.Background(), endpoint, token, username, password, logger, opts...)
 }
 
-func NewClientWithContext(ctx context.Context, endpoint, token, username, password *string, logger log.Logger) (*Client, error) {
+func NewClientWithContext(ctx context.Context, endpoint, token, username, password *string, logger log.Logger, opts ...ClientOption) (*Client, error) {
 
 	c := Client{
-		httpClient: &http.Client{Timeout: 10 * time.Second},
+		httpClient: &http.Client{Timeout: DefaultTimeout},
 		endpoint:   *endpoint,
 		token:      *token,
 		auth: AuthStruct{
 			Username: *username,
 			Password: *password,
 		},
-		logger: logger,
+		logger:       logger,
+		maxRetries:   DefaultMaxRetries,
+		retryWaitMin: DefaultRetryWaitMin,
+		retryWaitMax: DefaultRetryWaitMax,
+	}
+	for _, opt := range opts {
+		if err := opt(&c); err != nil {
+			return nil, err
+		}
 	}
 
 	// do login if we have no token
@@ -71,9 +105,40 @@ func NewClientWithContext(ctx context.Context, endpoint, token, username, passwo
 	return &c, nil
 }
 
+// ErrSessionExpired is returned when DIM does not accept the session token
+// and the client has no username and password to login again
+var ErrSessionExpired = errors.New("DIM session expired or invalid")
+
+// doRequest performs the request with the session token.
+// If the session has expired and username and password are known,
+// it logs in again and retries the request once.
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	token := c.sessionToken()
+	body, err := c.doRequestWithToken(req, token)
+	if !errors.Is(err, ErrSessionExpired) || c.auth.Username == "" || c.auth.Password == "" {
+		return body, err
+	}
 
-	req.AddCookie(&http.Cookie{Name: "session", Value: c.token})
+	if c.logger != nil {
+		level.Info(c.logger).Log("msg", "DIM session expired, logging in again")
+	}
+	if err := c.relogin(req.Context(), token); err != nil {
+		return nil, fmt.Errorf("%w, could not login to DIM again, %s", ErrSessionExpired, err)
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	return c.doRequestWithToken(retry, c.sessionToken())
+}
+
+func (c *Client) doRequestWithToken(req *http.Request, token string) ([]byte, error) {
+
+	req.Header.Del("Cookie")
+	req.AddCookie(&http.Cookie{Name: "session", Value: token})
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
@@ -86,14 +151,50 @@ func (c *Client) doRequest(req *http.Request) ([]byte, error) {
 		return nil, err
 	}
 
+	// the overload and gateway errors are retried, whatever the body,
+	// e.g. the html page of a load balancer
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return nil, &statusError{StatusCode: res.StatusCode, Body: body}
+	}
+
+	// An invalid session is answered with 401, a redirect to the login page
+	// or the SSO login page. DIM returns the json responses as text/html,
+	// so the SSO page can only be recognized by its content. The html pages
+	// of proxies are returned with an error status and are not sessions expired.
+	redirected := res.Request.URL.Path != req.URL.Path
+	if res.StatusCode == http.StatusUnauthorized ||
+		redirected && strings.HasSuffix(res.Request.URL.Path, "/login") ||
+		res.StatusCode == http.StatusOK && bytes.HasPrefix(bytes.TrimSpace(body), []byte("<")) {
+		return nil, fmt.Errorf("%w (status: %d, url: %s)", ErrSessionExpired, res.StatusCode, res.Request.URL)
+	}
+
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		return nil, &statusError{StatusCode: res.StatusCode, Body: body}
 	}
 
 	return body, err
 }
 
-// obtains token (session cookie) from DIM
+func (c *Client) sessionToken() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+// relogin obtains a new token unless the expired token has been
+// replaced already by a concurrent request
+func (c *Client) relogin(ctx context.Context, expired string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != expired {
+		return nil
+	}
+	return c.doLoginWithContext(ctx)
+}
+
+// obtains token (session cookie) from DIM,
+// the caller must hold c.mu unless the client is not shared yet
 func (c *Client) doLoginWithContext(ctx context.Context) error {
 	if c.auth.Username == "" || c.auth.Password == "" {
 		return fmt.Errorf("define username and password")
@@ -124,11 +225,72 @@ func (c *Client) doLoginWithContext(ctx context.Context) error {
 	return nil
 }
 
+// Token returns the session token (cookie) of the client,
+// e.g. to store it for the later clients
+func (c *Client) Token() string {
+	return c.sessionToken()
+}
+
+// Logout ends the DIM session of the client
+func (c *Client) Logout(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/logout", c.endpoint), nil)
+	if err != nil {
+		return err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.token})
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// DIM redirects to the login page after logout
+	if res.StatusCode >= http.StatusBadRequest {
+		body, _ := io.ReadAll(res.Body)
+		return &statusError{StatusCode: res.StatusCode, Body: body}
+	}
+	c.token = ""
+	return nil
+}
+
 func (c *Client) RawCall(function string, args interface{}) (any, error) {
 	return c.RawCallWithContext(context.Background(), function, args)
 }
 
 func (c *Client) RawCallWithContext(ctx context.Context, function string, args interface{}) (any, error) {
+	var res any
+	if err := c.callWithContext(ctx, function, args, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// callWithContext calls the DIM function and unmarshals its result
+// into the value pointed to by result
+func (c *Client) callWithContext(ctx context.Context, function string, args interface{}, result any) error {
+	res, err := c.call(ctx, function, args)
+	if c.callLogger != nil {
+		c.callLogger(ctx, function, args, res, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if result == nil || len(res) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(res, result); err != nil {
+		return fmt.Errorf("unexpected %s result: %s", function, err)
+	}
+	return nil
+}
+
+// call calls the DIM function and returns its raw result
+func (c *Client) call(ctx context.Context, function string, args interface{}) (json.RawMessage, error) {
 
 	/*
 		if rArgs := reflect.ValueOf(args); rArgs.Kind() == reflect.Struct {
@@ -141,25 +303,38 @@ func (c *Client) RawCallWithContext(ctx context.Context, function string, args i
 		level.Info(c.logger).Log("msg", "exec dim call", "payload", string(body))
 	}
 	//fmt.Println(string(body)) //debug
-	buf := bytes.NewBuffer(body)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/jsonrpc", c.endpoint), buf)
-	if err != nil {
-		return nil, err
-	}
+	var resBody []byte
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/jsonrpc", c.endpoint), bytes.NewBuffer(body))
+		if err != nil {
+			return nil, err
+		}
 
-	resBody, err := c.doRequest(req)
-	if err != nil {
-		return nil, fmt.Errorf("could not perform DIM request, %s", err)
+		resBody, err = c.doRequest(req)
+		if err == nil {
+			break
+		}
+		if attempt >= c.maxRetries || ctx.Err() != nil || !retryable(function, err) {
+			return nil, fmt.Errorf("could not perform DIM request, %w", err)
+		}
+
+		wait := c.backoff(attempt)
+		if c.logger != nil {
+			level.Warn(c.logger).Log("msg", "retrying dim call", "func", function, "attempt", attempt+1, "wait", wait, "err", err)
+		}
+		if err := sleepWithContext(ctx, wait); err != nil {
+			return nil, fmt.Errorf("could not perform DIM request, %w", err)
+		}
 	}
 
 	var rawRe rawResponse
 	// DIM seemingly always returnd Content-Type="text/html", so we have to expect json and unmarshal it.
-	// In case of incorrectly specified dim url, it might be a response (without redirect) from SSO page,
-	// which will fail unmarshal
+	// The SSO page (e.g. in case of incorrectly specified dim url) is already rejected by doRequest,
+	// other unexpected responses will fail unmarshal
 	err = json.Unmarshal(resBody, &rawRe)
 	if err != nil {
 		return nil, fmt.Errorf("could not unmarshal DIM response (is specified dim url correct?): %s", err)
@@ -171,6 +346,51 @@ func (c *Client) RawCallWithContext(ctx context.Context, function string, args i
 	return rawRe.Result, nil
 }
 
+// mergeArgs flattens the given values (structs with json tags or maps)
+// into a single DIM options object.
+// Later values override the keys of the earlier ones.
+func mergeArgs(vs ...any) (map[string]any, error) {
+	res := map[string]any{}
+	for _, v := range vs {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		var m map[string]any
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		if err := dec.Decode(&m); err != nil {
+			return nil, err
+		}
+		for k, mv := range m {
+			res[k] = mv
+		}
+	}
+	return res, nil
+}
+
+// customAttrs returns the string valued attributes of the DIM object data
+// which are not mapped to any field of v (a struct with json tags),
+// i.e. the user defined attributes of the object
+func customAttrs(data []byte, v any) (map[string]string, error) {
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		delete(m, name)
+	}
+	res := map[string]string{}
+	for k, mv := range m {
+		if s, ok := mv.(string); ok {
+			res[k] = s
+		}
+	}
+	return res, nil
+}
+
 // structToKVList coverts arbitrary struct to []map[string]interface{}
 // where each element of the slice is a map with single key, value, e.g map[string]interface{"key": 1}
 // Primarily used for convirting struct args to dim API args (json array of {"key": value} )
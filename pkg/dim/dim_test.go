@@ -1,8 +1,18 @@
 package dim
 
 import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestStructToKVList(t *testing.T) {
@@ -23,12 +33,21 @@ func TestStructToKVList(t *testing.T) {
 	}{
 		{
 			input: dimRR{Name: "test01", Type: "TXT", Zone: "example.com", Ip: "10.10.10.10", Overwrite: true},
+			// in the order of the struct fields
 			wants: []map[string]interface{}{
 				{"name": "test01"},
 				{"type": "TXT"},
 				{"zone": "example.com"},
-				{"ip": "10.10.10.10"},
 				{"overwrite": true},
+				{"ip": "10.10.10.10"},
+			},
+		},
+		{
+			// the empty fields without omitempty are kept
+			input: dimRR{},
+			wants: []map[string]interface{}{
+				{"name": ""},
+				{"type": ""},
 			},
 		},
 	}
@@ -64,3 +83,387 @@ func myMapEqu(x, y map[string]interface{}) bool {
 	}
 	return true
 }
+
+func TestMergeArgs(t *testing.T) {
+	ttl := int64(300)
+	tests := []struct {
+		input []any
+		wants string
+	}{
+		{
+			input: []any{
+				RR{Type: "A", Name: "test01", Zone: "example.com", IP: "10.10.10.10"},
+				RRCreateOptions{TTL: &ttl},
+			},
+			wants: `{"ip":"10.10.10.10","name":"test01","ttl":300,"type":"A","zone":"example.com"}`,
+		},
+		{
+			input: []any{
+				RR{Type: "TXT", Name: "test01.example.com.", Strings: []string{"a", "b"}},
+				RRDeleteOptions{References: "warn"},
+			},
+			wants: `{"name":"test01.example.com.","references":"warn","strings":["a","b"],"type":"TXT"}`,
+		},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		got, err := mergeArgs(tests[i].input...)
+		if err != nil {
+			t.Fatalf("mergeArgs(%#v) error: %s", tests[i].input, err)
+		}
+		if gotJson, _ := json.Marshal(got); string(gotJson) != tests[i].wants {
+			t.Errorf("mergeArgs(%#v) = %s ; wants = %s", tests[i].input, gotJson, tests[i].wants)
+		}
+	}
+}
+
+func TestRRMarshalJSON(t *testing.T) {
+	tests := []struct {
+		input RR
+		wants string
+	}{
+		{
+			input: RR{Type: "CNAME", Name: "www", Zone: "example.com", CNAME: "example.com."},
+			wants: `{"type":"CNAME","name":"www","zone":"example.com","cname":"example.com."}`,
+		},
+		{
+			input: RR{Type: "SSHFP", Name: "host.example.com.", Fields: map[string]any{"algorithm": "4", "fingerprint_type": "2", "fingerprint": "abcd"}},
+			wants: `{"algorithm":"4","fingerprint":"abcd","fingerprint_type":"2","name":"host.example.com.","type":"SSHFP"}`,
+		},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		got, err := json.Marshal(tests[i].input)
+		if err != nil {
+			t.Fatalf("json.Marshal(%#v) error: %s", tests[i].input, err)
+		}
+		if string(got) != tests[i].wants {
+			t.Errorf("json.Marshal(%#v) = %s ; wants = %s", tests[i].input, got, tests[i].wants)
+		}
+	}
+}
+
+func TestIPPoolAttrsUnmarshalJSON(t *testing.T) {
+	data := `{"name":"some-pool","vlan":42,"layer3domain":"default","description":"some description","owner":"network","subnets":1}`
+	var got IPPoolAttrs
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) error: %s", data, err)
+	}
+	if got.Name != "some-pool" || got.VLAN == nil || *got.VLAN != 42 || got.Description == nil || *got.Description != "some description" {
+		t.Errorf("json.Unmarshal(%s) = %+v", data, got)
+	}
+	if len(got.Attributes) != 1 || got.Attributes["owner"] != "network" {
+		t.Errorf("json.Unmarshal(%s).Attributes = %v ; wants = %v", data, got.Attributes, map[string]string{"owner": "network"})
+	}
+}
+
+func TestIPBlockAttrsUnmarshalJSON(t *testing.T) {
+	data := `{"ip":"10.0.0.0/8","layer3domain":"default","status":"Container","comment":"some comment","site":"dc1"}`
+	var got IPBlockAttrs
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) error: %s", data, err)
+	}
+	if got.IP != "10.0.0.0/8" || got.Status != "Container" || got.Comment == nil || *got.Comment != "some comment" {
+		t.Errorf("json.Unmarshal(%s) = %+v", data, got)
+	}
+	if len(got.Attributes) != 1 || got.Attributes["site"] != "dc1" {
+		t.Errorf("json.Unmarshal(%s).Attributes = %v ; wants = %v", data, got.Attributes, map[string]string{"site": "dc1"})
+	}
+}
+
+func TestRelogin(t *testing.T) {
+	tests := []struct {
+		name     string
+		expired  func(w http.ResponseWriter, r *http.Request)
+		username string
+		wantErr  bool
+	}{
+		{
+			name: "unauthorized",
+			expired: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			username: "user",
+		},
+		{
+			name: "redirect",
+			expired: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "/login", http.StatusFound)
+			},
+			username: "user",
+		},
+		{
+			name: "sso page",
+			expired: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, "\n<!DOCTYPE html><html><body>SSO login</body></html>")
+			},
+			username: "user",
+		},
+		{
+			name: "no credentials",
+			expired: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logins := 0
+			session := "current"
+			mux := http.NewServeMux()
+			mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					fmt.Fprint(w, "<html><body>login form</body></html>")
+					return
+				}
+				logins++
+				session = fmt.Sprintf("session%d", logins)
+				http.SetCookie(w, &http.Cookie{Name: "session", Value: session})
+			})
+			mux.HandleFunc("/jsonrpc", func(w http.ResponseWriter, r *http.Request) {
+				if cookie, err := r.Cookie("session"); err != nil || cookie.Value != session {
+					tt.expired(w, r)
+					return
+				}
+				fmt.Fprint(w, `{"jsonrpc":"2.0","result":"ok","id":null}`)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			token := "expired"
+			password := tt.username
+			c, err := NewClient(&server.URL, &token, &tt.username, &password, nil)
+			if err != nil {
+				t.Fatalf("NewClient() error: %s", err)
+			}
+			got, err := c.RawCall("some_func", []any{})
+			if tt.wantErr {
+				if !errors.Is(err, ErrSessionExpired) {
+					t.Errorf("RawCall() error = %v ; wants = %s", err, ErrSessionExpired)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RawCall() error: %s", err)
+			}
+			if got != "ok" || logins != 1 {
+				t.Errorf("RawCall() = %v with %d logins ; wants = ok with 1 login", got, logins)
+			}
+		})
+	}
+}
+
+func TestSessionNotExpired(t *testing.T) {
+	logins := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		logins++
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	mux.HandleFunc("/jsonrpc", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, "<html><body>maintenance</body></html>")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	token, username := "session", "user"
+	c, err := NewClient(&server.URL, &token, &username, &username, nil)
+	if err != nil {
+		t.Fatalf("NewClient() error: %s", err)
+	}
+	_, err = c.RawCall("some_func", []any{})
+	var statusErr *statusError
+	if !errors.As(err, &statusErr) || errors.Is(err, ErrSessionExpired) || logins != 0 {
+		t.Errorf("RawCall() error = %v with %d logins ; wants status error without login", err, logins)
+	}
+}
+
+func TestReloginFailed(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	mux.HandleFunc("/jsonrpc", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	token, username := "expired", "user"
+	c, err := NewClient(&server.URL, &token, &username, &username, nil)
+	if err != nil {
+		t.Fatalf("NewClient() error: %s", err)
+	}
+	if _, err := c.RawCall("some_func", []any{}); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("RawCall() error = %v ; wants = %s", err, ErrSessionExpired)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	sessions := map[string]bool{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			fmt.Fprint(w, "<html><body>login form</body></html>")
+			return
+		}
+		sessions["session1"] = true
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "session1"})
+	})
+	mux.HandleFunc("/logout", func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie("session"); err == nil {
+			delete(sessions, cookie.Value)
+		}
+		http.Redirect(w, r, "/login", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	token, username, password := "", "user", "user"
+	c, err := NewClient(&server.URL, &token, &username, &password, nil)
+	if err != nil {
+		t.Fatalf("NewClient() error: %s", err)
+	}
+	if got := c.Token(); got != "session1" {
+		t.Errorf("Token() = %q ; wants = session1", got)
+	}
+	if err := c.Logout(context.Background()); err != nil {
+		t.Fatalf("Logout() error: %s", err)
+	}
+	if c.Token() != "" || len(sessions) != 0 {
+		t.Errorf("Logout() left token %q and sessions %v", c.Token(), sessions)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		function  string
+		failures  int
+		status    int
+		body      string
+		wantCalls int
+		wantErr   bool
+	}{
+		{function: "rr_get_attrs", failures: 2, status: http.StatusBadGateway, wantCalls: 3},
+		{function: "zone_get_attrs", failures: 2, status: http.StatusBadGateway, body: "<html><body>502 Bad Gateway</body></html>", wantCalls: 3},
+		{function: "rr_list", failures: 5, status: http.StatusServiceUnavailable, wantCalls: 4, wantErr: true},
+		{function: "ipblock_get_attrs", failures: 1, status: http.StatusInternalServerError, wantCalls: 1, wantErr: true},
+		{function: "rr_create", failures: 1, status: http.StatusBadGateway, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls <= tt.failures {
+					w.WriteHeader(tt.status)
+					fmt.Fprint(w, tt.body)
+					return
+				}
+				fmt.Fprint(w, `{"jsonrpc":"2.0","result":"ok","id":null}`)
+			}))
+			defer server.Close()
+
+			token, username := "session", ""
+			c, err := NewClient(&server.URL, &token, &username, &username, nil, WithRetry(3, time.Millisecond, 2*time.Millisecond))
+			if err != nil {
+				t.Fatalf("NewClient() error: %s", err)
+			}
+			_, err = c.RawCall(tt.function, []any{})
+			if (err != nil) != tt.wantErr || calls != tt.wantCalls {
+				t.Errorf("RawCall(%q) error = %v after %d calls ; wants error %t after %d calls", tt.function, err, calls, tt.wantErr, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWithCallLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","result":{"ip":"10.0.0.1"},"id":null}`)
+	}))
+	defer server.Close()
+
+	var logged []string
+	logger := func(_ context.Context, function string, args any, result json.RawMessage, err error) {
+		jsonArgs, _ := json.Marshal(args)
+		logged = append(logged, fmt.Sprintf("%s %s %s %v", function, jsonArgs, result, err))
+	}
+	token, username := "session", ""
+	c, err := NewClient(&server.URL, &token, &username, &username, nil, WithCallLogger(logger))
+	if err != nil {
+		t.Fatalf("NewClient() error: %s", err)
+	}
+	if _, err := c.IPBlockGetAttrs(context.Background(), "10.0.0.1", IPBlockOptions{Host: true}); err != nil {
+		t.Fatalf("IPBlockGetAttrs() error: %s", err)
+	}
+	wants := []string{`ipblock_get_attrs ["10.0.0.1",{"host":true}] {"ip":"10.0.0.1"} <nil>`}
+	if !reflect.DeepEqual(logged, wants) {
+		t.Errorf("logged calls = %q ; wants %q", logged, wants)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	c := Client{retryWaitMin: time.Second, retryWaitMax: 5 * time.Second}
+	tests := []struct {
+		attempt int
+		wantMax time.Duration
+	}{
+		{attempt: 0, wantMax: time.Second},
+		{attempt: 1, wantMax: 2 * time.Second},
+		{attempt: 2, wantMax: 4 * time.Second},
+		{attempt: 3, wantMax: 5 * time.Second},
+		{attempt: 100, wantMax: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 100; i++ {
+			if got := c.backoff(tt.attempt); got < tt.wantMax/2 || got > tt.wantMax {
+				t.Fatalf("backoff(%d) = %s ; wants between %s and %s", tt.attempt, got, tt.wantMax/2, tt.wantMax)
+			}
+		}
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","result":"ok","id":null}`)
+	}))
+	defer server.Close()
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caCertFile, caCert, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    TransportOptions
+		wantErr bool
+	}{
+		{name: "system CAs", opts: TransportOptions{}, wantErr: true},
+		{name: "CA bundle", opts: TransportOptions{CACertFile: caCertFile}},
+		{name: "insecure", opts: TransportOptions{InsecureSkipVerify: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, username := "session", ""
+			c, err := NewClient(&server.URL, &token, &username, &username, nil, WithTransport(tt.opts), WithRetry(0, 0, 0))
+			if err != nil {
+				t.Fatalf("NewClient() error: %s", err)
+			}
+			if _, err := c.RawCall("server_info", []any{}); (err != nil) != tt.wantErr {
+				t.Errorf("RawCall() error = %v ; wants error %t", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := NewHTTPClient(TransportOptions{CACertFile: filepath.Join(t.TempDir(), "missing.pem")}); err == nil {
+		t.Errorf("NewHTTPClient() with missing CA file succeeded")
+	}
+}
@@ -0,0 +1,307 @@
+// Package dimtest provides an in-memory DIM stand-in for tests.
+//
+// The Server speaks the /login, /logout and /jsonrpc protocol used by dim.Client
+// and implements the subset of the DIM functions used by the provider:
+// ippool_get_ip, ip_mark, ip_free, ipblock_get_attrs, ipblock_set_attrs,
+// ipblock_delete_attrs, layer3domain_list, rr_create, rr_get_attrs,
+// rr_set_attrs, rr_delete, rr_list, zone_create, zone_get_attrs, zone_set_attrs,
+// zone_get_soa_attrs, zone_set_soa_attrs, zone_delete, zone_list_views,
+// zone_create_view, zone_rename_view and zone_delete_view.
+// Zone profiles are not supported.
+package dimtest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync"
+	"time"
+)
+
+const (
+	// Username and Password are the credentials accepted by /login
+	Username = "dimtest"
+	Password = "dimtest"
+
+	// DefaultLayer3domain is the layer 3 domain of the pools
+	DefaultLayer3domain = "default"
+	// DefaultView is the view of the zones added without views
+	DefaultView = "default"
+)
+
+// The codes of the DIM errors returned by the Server
+const (
+	// CodeDimError is the generic error, e.g. a record not found
+	CodeDimError = 1
+	// CodeInvalidPool is returned for an unknown pool
+	CodeInvalidPool = 4
+	// CodeInvalidIP is returned for an unknown or not allowed ip address
+	CodeInvalidIP = 5
+	// CodeInvalidZone is returned for an unknown zone
+	CodeInvalidZone = 6
+	// CodeInvalidView is returned for an unknown or ambiguous view
+	CodeInvalidView = 7
+	// CodeAlreadyExists is returned when creating an existing record
+	CodeAlreadyExists = 8
+	// CodeInvalidParameter is returned for the unknown options or missing arguments
+	CodeInvalidParameter = 19
+
+	// codeMethodNotFound is the JSON-RPC error of an unknown function
+	codeMethodNotFound = -32601
+)
+
+// Error is a DIM error returned by a function
+type Error struct {
+	Code    int64  `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("error (%d): %s", e.Code, e.Message)
+}
+
+func errorf(code int64, format string, a ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, a...)}
+}
+
+// Server is an in-memory DIM listening on a local http server
+type Server struct {
+	// URL is the DIM endpoint, i.e. the base of /login, /logout and /jsonrpc
+	URL string
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	sessions map[string]string // session token -> username
+	pools    map[string]*pool
+	ips      map[ipKey]*ipBlock
+	zones    map[string]*zone
+	rrs      []*rr
+}
+
+// NewServer starts and returns a new Server,
+// the caller should call Close when finished
+func NewServer() *Server {
+	s := &Server{
+		sessions: map[string]string{},
+		pools:    map[string]*pool{},
+		ips:      map[ipKey]*ipBlock{},
+		zones:    map[string]*zone{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", s.handleLogin)
+	mux.HandleFunc("/logout", s.handleLogout)
+	mux.HandleFunc("/jsonrpc", s.handleJSONRPC)
+	s.server = httptest.NewServer(mux)
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Login returns a new session token, as if Username logged in
+func (s *Server) Login() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.newSession(Username)
+}
+
+// ExpireSessions invalidates all session tokens
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = map[string]string{}
+}
+
+// AddPool adds the ip pool with the subnets in DefaultLayer3domain.
+// The first address of a subnet is its gateway,
+// the network and broadcast addresses of IPv4 subnets are reserved.
+func (s *Server) AddPool(name string, subnets ...string) error {
+	p := &pool{name: name, layer3domain: DefaultLayer3domain}
+	for _, subnet := range subnets {
+		prefix, err := netip.ParsePrefix(subnet)
+		if err != nil {
+			return err
+		}
+		p.subnets = append(p.subnets, prefix.Masked())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pools[name] = p
+	return nil
+}
+
+// AddZone adds the zone with the views, or with DefaultView if none are given
+func (s *Server) AddZone(name string, views ...string) {
+	if len(views) == 0 {
+		views = []string{DefaultView}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	created := now()
+	s.zones[name] = &zone{name: name, views: views, created: created, createdBy: Username, modified: created, modifiedBy: Username}
+}
+
+func (s *Server) newSession(username string) string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	token := hex.EncodeToString(b)
+	s.sessions[token] = username
+	return token
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		// the login page, e.g. after a redirect from /logout
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, "<html><body>login</body></html>")
+		return
+	}
+	if r.PostFormValue("username") != Username || r.PostFormValue("password") != Password {
+		http.Error(w, "invalid username or password", http.StatusUnauthorized)
+		return
+	}
+	s.mu.Lock()
+	token := s.newSession(Username)
+	s.mu.Unlock()
+	http.SetCookie(w, &http.Cookie{Name: "session", Value: token, Path: "/"})
+}
+
+// handleLogout ends the session and, like DIM, redirects to the login page
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie("session"); err == nil {
+		s.mu.Lock()
+		delete(s.sessions, cookie.Value)
+		s.mu.Unlock()
+	}
+	http.Redirect(w, r, "/login", http.StatusFound)
+}
+
+type rpcRequest struct {
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type rpcResponse struct {
+	JSONRPC string `json:"jsonrpc"`
+	Result  any    `json:"result"`
+	Error   *Error `json:"error,omitempty"`
+	ID      any    `json:"id"`
+}
+
+func (s *Server) handleJSONRPC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cookie, err := r.Cookie("session")
+	if err != nil || s.sessions[cookie.Value] == "" {
+		http.Error(w, "not logged in", http.StatusUnauthorized)
+		return
+	}
+	user := s.sessions[cookie.Value]
+
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	res := rpcResponse{JSONRPC: "2.0"}
+	if f, ok := functions[req.Method]; ok {
+		res.Result, res.Error = f(s, call{user: user, params: req.Params})
+	} else {
+		res.Error = errorf(codeMethodNotFound, "Method %s not found", req.Method)
+	}
+	if res.Error != nil {
+		res.Result = nil
+	}
+	// like DIM, the json is served as text/html
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	json.NewEncoder(w).Encode(res)
+}
+
+// call is a single DIM function call
+type call struct {
+	user   string
+	params []json.RawMessage
+}
+
+// args unmarshals the positional arguments of the call into vs,
+// the options object is optional and must not contain unknown options
+func (c call) args(vs ...any) *Error {
+	if len(c.params) > len(vs) {
+		return errorf(CodeInvalidParameter, "Too many arguments")
+	}
+	for i, v := range vs {
+		if i >= len(c.params) {
+			if i < len(vs)-1 {
+				return errorf(CodeInvalidParameter, "Missing argument")
+			}
+			break
+		}
+		dec := json.NewDecoder(bytes.NewReader(c.params[i]))
+		if i == len(vs)-1 {
+			dec.DisallowUnknownFields()
+		}
+		if err := dec.Decode(v); err != nil {
+			return errorf(CodeInvalidParameter, "Invalid argument %d: %s", i+1, err)
+		}
+	}
+	return nil
+}
+
+type function func(s *Server, c call) (any, *Error)
+
+// functions are the implemented DIM functions
+var functions = map[string]function{
+	"ippool_get_ip":        (*Server).ippoolGetIP,
+	"ip_mark":              (*Server).ipMark,
+	"ip_free":              (*Server).ipFree,
+	"ipblock_get_attrs":    (*Server).ipblockGetAttrs,
+	"ipblock_set_attrs":    (*Server).ipblockSetAttrs,
+	"ipblock_delete_attrs": (*Server).ipblockDeleteAttrs,
+	"layer3domain_list":    (*Server).layer3domainList,
+	"rr_create":            (*Server).rrCreate,
+	"rr_get_attrs":         (*Server).rrGetAttrs,
+	"rr_set_attrs":         (*Server).rrSetAttrs,
+	"rr_delete":            (*Server).rrDelete,
+	"rr_list":              (*Server).rrList,
+	"zone_create":          (*Server).zoneCreate,
+	"zone_get_attrs":       (*Server).zoneGetAttrs,
+	"zone_set_attrs":       (*Server).zoneSetAttrs,
+	"zone_get_soa_attrs":   (*Server).zoneGetSOAAttrs,
+	"zone_set_soa_attrs":   (*Server).zoneSetSOAAttrs,
+	"zone_delete":          (*Server).zoneDelete,
+	"zone_list_views":      (*Server).zoneListViews,
+	"zone_create_view":     (*Server).zoneCreateView,
+	"zone_rename_view":     (*Server).zoneRenameView,
+	"zone_delete_view":     (*Server).zoneDeleteView,
+}
+
+// layer3domainList lists DefaultLayer3domain, the only layer3domain of the Server
+func (s *Server) layer3domainList(c call) (any, *Error) {
+	if err := c.args(); err != nil {
+		return nil, err
+	}
+	return []map[string]any{{"name": DefaultLayer3domain, "type": "hosting", "rd": nil, "comment": nil}}, nil
+}
+
+// now returns the current time in the DIM format
+func now() string {
+	return time.Now().UTC().Format("2006-01-02 15:04:05.000000")
+}
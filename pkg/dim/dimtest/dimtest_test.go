@@ -0,0 +1,229 @@
+package dimtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"terraform-provider-ionosdim/pkg/dim"
+)
+
+func newTestClient(t *testing.T) (*Server, *dim.Client) {
+	t.Helper()
+	s := NewServer()
+	t.Cleanup(s.Close)
+	endpoint, token, username, password := s.URL, "", Username, Password
+	c, err := dim.NewClient(&endpoint, &token, &username, &password, nil, dim.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("NewClient() error: %s", err)
+	}
+	return s, c
+}
+
+func wantDimError(t *testing.T, err error, code int64) {
+	t.Helper()
+	var dimErr dim.Error
+	if !errors.As(err, &dimErr) || dimErr.Code != code {
+		t.Errorf("error = %v ; wants DIM error %d", err, code)
+	}
+}
+
+func TestIP(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestClient(t)
+	if err := s.AddPool("some-pool", "10.88.8.0/30"); err != nil {
+		t.Fatal(err)
+	}
+
+	// .0 and .3 are the network and broadcast addresses, .1 the gateway
+	ip, err := c.IPPoolGetIP(ctx, "some-pool", dim.IPPoolGetIPOptions{Attributes: map[string]string{"comment": "first"}})
+	if err != nil {
+		t.Fatalf("IPPoolGetIP() error: %s", err)
+	}
+	if ip.IP != "10.88.8.2" || ip.Status != "Static" || ip.Gateway != "10.88.8.1" || ip.Mask != "255.255.255.252" ||
+		ip.Comment == nil || *ip.Comment != "first" {
+		t.Errorf("IPPoolGetIP() = %+v", ip)
+	}
+	_, err = c.IPPoolGetIP(ctx, "some-pool", dim.IPPoolGetIPOptions{})
+	wantDimError(t, err, CodeDimError)
+	_, err = c.IPMark(ctx, "10.88.8.2", dim.IPMarkOptions{IPBlockOptions: dim.IPBlockOptions{Pool: "some-pool", Host: true}})
+	wantDimError(t, err, CodeInvalidIP)
+
+	opts := dim.IPBlockOptions{Host: true, Pool: "some-pool"}
+	if err := c.IPBlockSetAttrs(ctx, "10.88.8.2", map[string]string{"owner": "network"}, opts); err != nil {
+		t.Fatalf("IPBlockSetAttrs() error: %s", err)
+	}
+	if err := c.IPBlockDeleteAttrs(ctx, "10.88.8.2", []string{"comment"}, opts); err != nil {
+		t.Fatalf("IPBlockDeleteAttrs() error: %s", err)
+	}
+	ip, err = c.IPBlockGetAttrs(ctx, "10.88.8.2", opts)
+	if err != nil {
+		t.Fatalf("IPBlockGetAttrs() error: %s", err)
+	}
+	if ip.Comment != nil || len(ip.Attributes) != 1 || ip.Attributes["owner"] != "network" {
+		t.Errorf("IPBlockGetAttrs() = %+v", ip)
+	}
+
+	for _, want := range []int{1, 0} {
+		if got, err := c.IPFree(ctx, "10.88.8.2", opts); err != nil || got != want {
+			t.Errorf("IPFree() = %d, %v ; wants %d", got, err, want)
+		}
+	}
+	if got, err := c.IPFree(ctx, "10.88.8.3", opts); err != nil || got != -1 {
+		t.Errorf("IPFree() of the broadcast address = %d, %v ; wants -1", got, err)
+	}
+	_, err = c.IPBlockGetAttrs(ctx, "10.99.0.1", dim.IPBlockOptions{Host: true})
+	wantDimError(t, err, CodeInvalidIP)
+}
+
+func TestRR(t *testing.T) {
+	ctx := context.Background()
+	s, c := newTestClient(t)
+	s.AddZone("example.com", "internal", "external")
+
+	ttl, comment := int64(300), "some comment"
+	rr := dim.RR{Type: "TXT", Name: "host", Zone: "example.com", View: "internal", Strings: []string{"a b", "c"}}
+	if err := c.RRCreate(ctx, rr, dim.RRCreateOptions{TTL: &ttl}); err != nil {
+		t.Fatalf("RRCreate() error: %s", err)
+	}
+	wantDimError(t, c.RRCreate(ctx, rr, dim.RRCreateOptions{}), CodeAlreadyExists)
+	wantDimError(t, c.RRCreate(ctx, dim.RR{Type: "CNAME", Name: "www", Zone: "example.com", CNAME: "host"}, dim.RRCreateOptions{}), CodeInvalidView)
+	wantDimError(t, c.RRCreate(ctx, dim.RR{Type: "A", Name: "host.example.org.", IP: "10.0.0.1"}, dim.RRCreateOptions{}), CodeInvalidZone)
+
+	fqdn := dim.RR{Type: "TXT", Name: "host.example.com.", Strings: []string{"a b", "c"}}
+	if err := c.RRSetAttrs(ctx, fqdn, dim.RRSetAttrsOptions{Comment: &comment}); err != nil {
+		t.Fatalf("RRSetAttrs() error: %s", err)
+	}
+	attrs, err := c.RRGetAttrs(ctx, fqdn)
+	if err != nil {
+		t.Fatalf("RRGetAttrs() error: %s", err)
+	}
+	if attrs.RR != `host TXT "a b" "c"` || attrs.Zone != "example.com" || attrs.TTL == nil || *attrs.TTL != ttl ||
+		attrs.Comment == nil || *attrs.Comment != comment || attrs.CreatedBy != Username {
+		t.Errorf("RRGetAttrs() = %+v", attrs)
+	}
+
+	list, err := c.RRList(ctx, dim.RRListOptions{Pattern: "h*", Zone: "example.com", Fields: true})
+	if err != nil {
+		t.Fatalf("RRList() error: %s", err)
+	}
+	if len(list) != 1 || list[0].Record != "host" || list[0].View != "internal" || list[0].Comment != comment {
+		t.Errorf("RRList() = %+v", list)
+	}
+
+	if err := c.RRDelete(ctx, rr, dim.RRDeleteOptions{References: "warn"}); err != nil {
+		t.Fatalf("RRDelete() error: %s", err)
+	}
+	_, err = c.RRGetAttrs(ctx, fqdn)
+	wantDimError(t, err, CodeDimError)
+}
+
+func TestZone(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t)
+
+	primary, refresh := "ns1.example.com.", int64(7200)
+	opts := dim.ZoneCreateOptions{
+		SOAAttributes: &dim.ZoneSOAAttrs{Primary: primary},
+		Attributes:    map[string]string{"comment": "some comment"},
+	}
+	if err := c.ZoneCreate(ctx, "example.com", opts); err != nil {
+		t.Fatalf("ZoneCreate() error: %s", err)
+	}
+	wantDimError(t, c.ZoneCreate(ctx, "example.com", dim.ZoneCreateOptions{}), CodeAlreadyExists)
+	attrs, err := c.ZoneGetAttrs(ctx, "example.com", dim.ZoneOptions{})
+	if err != nil {
+		t.Fatalf("ZoneGetAttrs() error: %s", err)
+	}
+	if attrs.Name != "example.com" || attrs.CreatedBy != Username || attrs.Comment == nil || *attrs.Comment != "some comment" {
+		t.Errorf("ZoneGetAttrs() = %+v", attrs)
+	}
+
+	if err := c.ZoneRenameView(ctx, "example.com", DefaultView, "internal"); err != nil {
+		t.Fatalf("ZoneRenameView() error: %s", err)
+	}
+	if err := c.ZoneCreateView(ctx, "example.com", "external", dim.ZoneViewCreateOptions{}); err != nil {
+		t.Fatalf("ZoneCreateView() error: %s", err)
+	}
+	views, err := c.ZoneListViews(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("ZoneListViews() error: %s", err)
+	}
+	if len(views) != 2 || views[0].Name != "internal" || views[1].Name != "external" {
+		t.Errorf("ZoneListViews() = %+v", views)
+	}
+
+	_, err = c.ZoneGetSOAAttrs(ctx, "example.com", dim.ZoneViewOptions{})
+	wantDimError(t, err, CodeInvalidView)
+	if err := c.ZoneSetSOAAttrs(ctx, "example.com", dim.ZoneSOAAttrs{Refresh: &refresh}, dim.ZoneViewOptions{View: "internal"}); err != nil {
+		t.Fatalf("ZoneSetSOAAttrs() error: %s", err)
+	}
+	soa, err := c.ZoneGetSOAAttrs(ctx, "example.com", dim.ZoneViewOptions{View: "internal"})
+	if err != nil {
+		t.Fatalf("ZoneGetSOAAttrs() error: %s", err)
+	}
+	if soa.Primary != primary || soa.Refresh == nil || *soa.Refresh != refresh || soa.Serial == nil || *soa.Serial != 3 {
+		t.Errorf("ZoneGetSOAAttrs() = %+v", soa)
+	}
+
+	rr := dim.RR{Type: "CNAME", Name: "www", Zone: "example.com", View: "external", CNAME: "host"}
+	if err := c.RRCreate(ctx, rr, dim.RRCreateOptions{}); err != nil {
+		t.Fatalf("RRCreate() error: %s", err)
+	}
+	wantDimError(t, c.ZoneDeleteView(ctx, "example.com", "external", dim.ZoneViewDeleteOptions{}), CodeDimError)
+	if err := c.ZoneDeleteView(ctx, "example.com", "external", dim.ZoneViewDeleteOptions{Cleanup: true}); err != nil {
+		t.Fatalf("ZoneDeleteView() error: %s", err)
+	}
+	wantDimError(t, c.ZoneDeleteView(ctx, "example.com", "internal", dim.ZoneViewDeleteOptions{}), CodeDimError)
+	if err := c.ZoneDelete(ctx, "example.com", dim.ZoneDeleteOptions{}); err != nil {
+		t.Fatalf("ZoneDelete() error: %s", err)
+	}
+	_, err = c.ZoneGetAttrs(ctx, "example.com", dim.ZoneOptions{})
+	wantDimError(t, err, CodeInvalidZone)
+}
+
+func TestUnknownOptions(t *testing.T) {
+	s, c := newTestClient(t)
+	if err := s.AddPool("some-pool", "10.88.8.0/24"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := c.RawCall("ip_mark", []any{"10.88.8.10", map[string]any{"status": "Available"}})
+	wantDimError(t, err, CodeInvalidParameter)
+	_, err = c.RawCall("no_such_func", []any{})
+	var dimErr dim.Error
+	if !errors.As(err, &dimErr) {
+		t.Errorf("RawCall() of an unknown function error = %v ; wants DIM error", err)
+	}
+}
+
+func TestExpireSessions(t *testing.T) {
+	s, c := newTestClient(t)
+	s.AddZone("example.com")
+	s.ExpireSessions()
+	if _, err := c.RRList(context.Background(), dim.RRListOptions{Zone: "example.com"}); err != nil {
+		t.Errorf("RRList() after the session expired error: %s", err)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestClient(t)
+	endpoint, token, username, password := s.URL, s.Login(), "", ""
+	c, err := dim.NewClient(&endpoint, &token, &username, &password, nil, dim.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("NewClient() error: %s", err)
+	}
+	if _, err := c.Layer3domainList(ctx); err != nil {
+		t.Fatalf("Layer3domainList() error: %s", err)
+	}
+	if err := c.Logout(ctx); err != nil {
+		t.Fatalf("Logout() error: %s", err)
+	}
+	c, err = dim.NewClient(&endpoint, &token, &username, &password, nil, dim.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("NewClient() error: %s", err)
+	}
+	if _, err := c.Layer3domainList(ctx); !errors.Is(err, dim.ErrSessionExpired) {
+		t.Errorf("Layer3domainList() after logout error = %v ; wants %s", err, dim.ErrSessionExpired)
+	}
+}
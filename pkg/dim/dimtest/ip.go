@@ -0,0 +1,285 @@
+package dimtest
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+type pool struct {
+	name         string
+	layer3domain string
+	subnets      []netip.Prefix
+}
+
+type ipKey struct {
+	layer3domain string
+	ip           netip.Addr
+}
+
+// ipBlock is an address which is not Available
+// or has attributes, the other addresses are not stored
+type ipBlock struct {
+	status     string
+	attributes map[string]string // including the comment
+	created    string
+	modified   string
+	modifiedBy string
+}
+
+// ipOptions are the options selecting an address
+type ipOptions struct {
+	Host         bool   `json:"host"`
+	Layer3domain string `json:"layer3domain"`
+	Pool         string `json:"pool"`
+}
+
+// ipMarkOptions are the options of ip_mark
+type ipMarkOptions struct {
+	ipOptions
+	Attributes map[string]string `json:"attributes"`
+}
+
+// ippoolGetIPOptions are the options of ippool_get_ip
+type ippoolGetIPOptions struct {
+	Attributes map[string]string `json:"attributes"`
+}
+
+// ipLocation is an address with the pool and subnet it belongs to
+type ipLocation struct {
+	key    ipKey
+	pool   *pool
+	subnet netip.Prefix
+}
+
+// locate returns the location of the address ip selected by opts
+func (s *Server) locate(ip string, opts ipOptions) (*ipLocation, *Error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil, errorf(CodeInvalidIP, "Invalid IP %s", ip)
+	}
+	layer3domain := opts.Layer3domain
+	if layer3domain == "" {
+		layer3domain = DefaultLayer3domain
+	}
+	if opts.Pool != "" {
+		if _, ok := s.pools[opts.Pool]; !ok {
+			return nil, errorf(CodeInvalidPool, "Invalid pool %s", opts.Pool)
+		}
+	}
+	for _, p := range s.pools {
+		if p.layer3domain != layer3domain {
+			continue
+		}
+		for _, subnet := range p.subnets {
+			if !subnet.Contains(addr) {
+				continue
+			}
+			if opts.Pool != "" && opts.Pool != p.name {
+				return nil, errorf(CodeInvalidIP, "%s is not in pool %s", ip, opts.Pool)
+			}
+			return &ipLocation{key: ipKey{layer3domain: layer3domain, ip: addr}, pool: p, subnet: subnet}, nil
+		}
+	}
+	return nil, errorf(CodeInvalidIP, "No subnet found for %s in layer3domain %s", ip, layer3domain)
+}
+
+// status returns the status of the address at loc
+func (s *Server) status(loc *ipLocation) string {
+	if b, ok := s.ips[loc.key]; ok {
+		return b.status
+	}
+	addr := loc.key.ip
+	if addr == loc.subnet.Addr() || addr == gateway(loc.subnet) {
+		return "Reserved"
+	}
+	if addr.Is4() && addr == broadcast(loc.subnet) {
+		return "Reserved"
+	}
+	return "Available"
+}
+
+// setStatus changes the status of the address at loc,
+// the attributes are replaced if attrs is not nil
+func (s *Server) setStatus(loc *ipLocation, user, status string, attrs map[string]string) {
+	b, ok := s.ips[loc.key]
+	if !ok {
+		b = &ipBlock{attributes: map[string]string{}, created: now()}
+		s.ips[loc.key] = b
+	}
+	b.status = status
+	if attrs != nil {
+		b.attributes = map[string]string{}
+		for k, v := range attrs {
+			b.attributes[k] = v
+		}
+	}
+	b.modified = now()
+	b.modifiedBy = user
+}
+
+// ipAttrs returns the ipblock_get_attrs result of the address at loc
+func (s *Server) ipAttrs(loc *ipLocation) map[string]any {
+	res := map[string]any{
+		"ip":           loc.key.ip.String(),
+		"layer3domain": loc.key.layer3domain,
+		"status":       s.status(loc),
+		"pool":         loc.pool.name,
+		"subnet":       loc.subnet.String(),
+		"gateway":      gateway(loc.subnet).String(),
+	}
+	if loc.key.ip.Is4() {
+		res["mask"] = net.IP(net.CIDRMask(loc.subnet.Bits(), 32)).String()
+		a := loc.key.ip.As4()
+		res["reverse_zone"] = fmt.Sprintf("%d.%d.%d.in-addr.arpa", a[2], a[1], a[0])
+	}
+	if b, ok := s.ips[loc.key]; ok {
+		for k, v := range b.attributes {
+			res[k] = v
+		}
+		res["created"] = b.created
+		res["modified"] = b.modified
+		res["modified_by"] = b.modifiedBy
+	}
+	return res
+}
+
+func (s *Server) ippoolGetIP(c call) (any, *Error) {
+	var name string
+	var opts ippoolGetIPOptions
+	if err := c.args(&name, &opts); err != nil {
+		return nil, err
+	}
+	p, ok := s.pools[name]
+	if !ok {
+		return nil, errorf(CodeInvalidPool, "Invalid pool %s", name)
+	}
+	for _, subnet := range p.subnets {
+		for addr := subnet.Addr(); subnet.Contains(addr); addr = addr.Next() {
+			loc := &ipLocation{key: ipKey{layer3domain: p.layer3domain, ip: addr}, pool: p, subnet: subnet}
+			if s.status(loc) == "Available" {
+				s.setStatus(loc, c.user, "Static", opts.Attributes)
+				return s.ipAttrs(loc), nil
+			}
+		}
+	}
+	return nil, errorf(CodeDimError, "No more IPs available in pool %s", name)
+}
+
+func (s *Server) ipMark(c call) (any, *Error) {
+	var ip string
+	var opts ipMarkOptions
+	if err := c.args(&ip, &opts); err != nil {
+		return nil, err
+	}
+	loc, err := s.locate(ip, opts.ipOptions)
+	if err != nil {
+		return nil, err
+	}
+	if status := s.status(loc); status != "Available" {
+		return nil, errorf(CodeInvalidIP, "IP %s is %s", ip, status)
+	}
+	s.setStatus(loc, c.user, "Static", opts.Attributes)
+	return s.ipAttrs(loc), nil
+}
+
+func (s *Server) ipFree(c call) (any, *Error) {
+	var ip string
+	var opts ipOptions
+	if err := c.args(&ip, &opts); err != nil {
+		return nil, err
+	}
+	loc, err := s.locate(ip, opts)
+	if err != nil {
+		return nil, err
+	}
+	switch s.status(loc) {
+	case "Available":
+		return 0, nil
+	case "Reserved":
+		return -1, nil
+	}
+	delete(s.ips, loc.key)
+	return 1, nil
+}
+
+func (s *Server) ipblockGetAttrs(c call) (any, *Error) {
+	var ip string
+	var opts ipOptions
+	if err := c.args(&ip, &opts); err != nil {
+		return nil, err
+	}
+	loc, err := s.locate(ip, opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.ipAttrs(loc), nil
+}
+
+func (s *Server) ipblockSetAttrs(c call) (any, *Error) {
+	var ip string
+	var attrs map[string]string
+	var opts ipOptions
+	if err := c.args(&ip, &attrs, &opts); err != nil {
+		return nil, err
+	}
+	loc, err := s.locate(ip, opts)
+	if err != nil {
+		return nil, err
+	}
+	merged := map[string]string{}
+	if b, ok := s.ips[loc.key]; ok {
+		for k, v := range b.attributes {
+			merged[k] = v
+		}
+	}
+	for k, v := range attrs {
+		merged[k] = v
+	}
+	s.setStatus(loc, c.user, s.status(loc), merged)
+	return nil, nil
+}
+
+func (s *Server) ipblockDeleteAttrs(c call) (any, *Error) {
+	var ip string
+	var names []string
+	var opts ipOptions
+	if err := c.args(&ip, &names, &opts); err != nil {
+		return nil, err
+	}
+	loc, err := s.locate(ip, opts)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := s.ips[loc.key]
+	if !ok {
+		return nil, nil
+	}
+	for _, name := range names {
+		delete(b.attributes, name)
+	}
+	b.modified = now()
+	b.modifiedBy = c.user
+	return nil, nil
+}
+
+// gateway returns the gateway of the subnet, i.e. its first host address
+func gateway(subnet netip.Prefix) netip.Addr {
+	return subnet.Addr().Next()
+}
+
+// broadcast returns the last address of the subnet
+func broadcast(subnet netip.Prefix) netip.Addr {
+	a := subnet.Addr().AsSlice()
+	hostBits := len(a)*8 - subnet.Bits()
+	for i := len(a) - 1; i >= 0 && hostBits > 0; i-- {
+		n := hostBits
+		if n > 8 {
+			n = 8
+		}
+		a[i] |= byte(1<<n - 1)
+		hostBits -= n
+	}
+	addr, _ := netip.AddrFromSlice(a)
+	return addr
+}
@@ -0,0 +1,430 @@
+package dimtest
+
+import (
+	"fmt"
+	"net/netip"
+	"path"
+	"sort"
+	"strings"
+)
+
+type rr struct {
+	typ          string
+	name         string // relative to the zone, "@" for the apex
+	zone         string
+	view         string
+	layer3domain string // of A and AAAA records
+	value        string
+	ttl          *int64
+	comment      *string
+	created      string
+	createdBy    string
+	modified     string
+	modifiedBy   string
+}
+
+// rrArgs are the arguments of the rr_* functions
+// identifying the record and its changeable attributes
+type rrArgs struct {
+	Type         string   `json:"type"`
+	Name         string   `json:"name"`
+	Zone         string   `json:"zone"`
+	View         string   `json:"view"`
+	Views        []string `json:"views"`
+	Layer3domain string   `json:"layer3domain"`
+
+	IP         string   `json:"ip"`
+	CNAME      string   `json:"cname"`
+	Strings    []string `json:"strings"`
+	PTRDName   string   `json:"ptrdname"`
+	Preference *int64   `json:"preference"`
+	Exchange   string   `json:"exchange"`
+	Priority   *int64   `json:"priority"`
+	Weight     *int64   `json:"weight"`
+	Port       *int64   `json:"port"`
+	Target     string   `json:"target"`
+
+	// rr_create, rr_set_attrs
+	TTL       *int64  `json:"ttl"`
+	Comment   *string `json:"comment"`
+	Overwrite bool    `json:"overwrite"`
+	// rr_delete
+	References string `json:"references"`
+	FreeIPs    bool   `json:"free_ips"`
+}
+
+// rrListOptions are the filters of rr_list
+type rrListOptions struct {
+	Type         string `json:"type"`
+	Pattern      string `json:"pattern"`
+	Zone         string `json:"zone"`
+	View         string `json:"view"`
+	Layer3domain string `json:"layer3domain"`
+	CreatedBy    string `json:"created_by"`
+	Comment      string `json:"comment"`
+	Fields       bool   `json:"fields"`
+}
+
+// value returns the value of the record in the zone file format
+func (a rrArgs) value() (string, *Error) {
+	missing := errorf(CodeInvalidParameter, "Missing value of the %s record", a.Type)
+	switch a.Type {
+	case "A", "AAAA":
+		addr, err := netip.ParseAddr(a.IP)
+		if err != nil {
+			return "", errorf(CodeInvalidIP, "Invalid IP %s", a.IP)
+		}
+		if addr.Is4() != (a.Type == "A") {
+			return "", errorf(CodeInvalidIP, "Invalid IP %s for %s record", a.IP, a.Type)
+		}
+		return addr.String(), nil
+	case "CNAME":
+		if a.CNAME == "" {
+			return "", missing
+		}
+		return a.CNAME, nil
+	case "TXT":
+		if len(a.Strings) == 0 {
+			return "", missing
+		}
+		quoted := make([]string, len(a.Strings))
+		for i, s := range a.Strings {
+			quoted[i] = fmt.Sprintf("%q", s)
+		}
+		return strings.Join(quoted, " "), nil
+	case "PTR":
+		if a.PTRDName == "" {
+			return "", missing
+		}
+		return a.PTRDName, nil
+	case "MX":
+		if a.Preference == nil || a.Exchange == "" {
+			return "", missing
+		}
+		return fmt.Sprintf("%d %s", *a.Preference, a.Exchange), nil
+	case "SRV":
+		if a.Priority == nil || a.Weight == nil || a.Port == nil || a.Target == "" {
+			return "", missing
+		}
+		return fmt.Sprintf("%d %d %d %s", *a.Priority, *a.Weight, *a.Port, a.Target), nil
+	}
+	return "", errorf(CodeInvalidParameter, "Unsupported record type %q", a.Type)
+}
+
+// resolve returns the zone of the record and its name relative to the zone
+func (s *Server) resolve(a rrArgs) (*zone, string, *Error) {
+	if a.Zone != "" {
+		z, ok := s.zones[a.Zone]
+		if !ok {
+			return nil, "", errorf(CodeInvalidZone, "Zone %s does not exist", a.Zone)
+		}
+		switch {
+		case a.Name == "" || a.Name == "@" || a.Name == z.name+".":
+			return z, "@", nil
+		case strings.HasSuffix(a.Name, "."+z.name+"."):
+			return z, strings.TrimSuffix(a.Name, "."+z.name+"."), nil
+		case strings.HasSuffix(a.Name, "."):
+			return nil, "", errorf(CodeInvalidParameter, "%s is not in zone %s", a.Name, z.name)
+		}
+		return z, a.Name, nil
+	}
+
+	if !strings.HasSuffix(a.Name, ".") {
+		return nil, "", errorf(CodeInvalidParameter, "A zone must be given for the relative name %q", a.Name)
+	}
+	// the longest zone the name belongs to
+	var found *zone
+	for _, z := range s.zones {
+		if (a.Name == z.name+"." || strings.HasSuffix(a.Name, "."+z.name+".")) &&
+			(found == nil || len(z.name) > len(found.name)) {
+			found = z
+		}
+	}
+	if found == nil {
+		return nil, "", errorf(CodeInvalidZone, "No zone found for %s", a.Name)
+	}
+	if a.Name == found.name+"." {
+		return found, "@", nil
+	}
+	return found, strings.TrimSuffix(a.Name, "."+found.name+"."), nil
+}
+
+// views returns the views of the zone selected by a,
+// all views of the zone if none are selected
+func (a rrArgs) views(z *zone) ([]string, *Error) {
+	selected := a.Views
+	if a.View != "" {
+		selected = append([]string{a.View}, selected...)
+	}
+	if len(selected) == 0 {
+		return z.views, nil
+	}
+	for _, v := range selected {
+		found := false
+		for _, zv := range z.views {
+			found = found || v == zv
+		}
+		if !found {
+			return nil, errorf(CodeInvalidView, "View %s of zone %s does not exist", v, z.name)
+		}
+	}
+	return selected, nil
+}
+
+// find returns the records identified by a
+func (s *Server) find(a rrArgs) ([]*rr, *Error) {
+	z, name, err := s.resolve(a)
+	if err != nil {
+		return nil, err
+	}
+	views, err := a.views(z)
+	if err != nil {
+		return nil, err
+	}
+	value, err := a.value()
+	if err != nil {
+		return nil, err
+	}
+	var res []*rr
+	for _, r := range s.rrs {
+		if r.zone != z.name || r.name != name || r.typ != a.Type || r.value != value {
+			continue
+		}
+		if a.Layer3domain != "" && r.layer3domain != "" && r.layer3domain != a.Layer3domain {
+			continue
+		}
+		for _, v := range views {
+			if r.view == v {
+				res = append(res, r)
+			}
+		}
+	}
+	return res, nil
+}
+
+// findOne returns the single record identified by a
+func (s *Server) findOne(a rrArgs) (*rr, *Error) {
+	found, err := s.find(a)
+	if err != nil {
+		return nil, err
+	}
+	switch len(found) {
+	case 0:
+		return nil, errorf(CodeDimError, "No %s RR found for %s", a.Type, a.Name)
+	case 1:
+		return found[0], nil
+	}
+	return nil, errorf(CodeDimError, "More than one %s RR found for %s, specify the view", a.Type, a.Name)
+}
+
+func (s *Server) rrCreate(c call) (any, *Error) {
+	var a rrArgs
+	if err := c.args(&a); err != nil {
+		return nil, err
+	}
+	z, name, err := s.resolve(a)
+	if err != nil {
+		return nil, err
+	}
+	views, err := a.views(z)
+	if err != nil {
+		return nil, err
+	}
+	if a.View == "" && len(a.Views) == 0 && len(views) > 1 {
+		return nil, errorf(CodeInvalidView, "Zone %s has multiple views, specify the view", z.name)
+	}
+	value, err := a.value()
+	if err != nil {
+		return nil, err
+	}
+	found, err := s.find(a)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) > 0 {
+		return nil, errorf(CodeAlreadyExists, "%s %s %s already exists", name, a.Type, value)
+	}
+
+	var layer3domain string
+	if a.Type == "A" || a.Type == "AAAA" {
+		layer3domain = a.Layer3domain
+		if layer3domain == "" {
+			layer3domain = DefaultLayer3domain
+		}
+		// like DIM, allocate the address if it is available
+		if loc, err := s.locate(a.IP, ipOptions{Layer3domain: layer3domain}); err == nil && s.status(loc) == "Available" {
+			s.setStatus(loc, c.user, "Static", nil)
+		}
+	}
+
+	for _, v := range views {
+		created := now()
+		s.rrs = append(s.rrs, &rr{
+			typ:          a.Type,
+			name:         name,
+			zone:         z.name,
+			view:         v,
+			layer3domain: layer3domain,
+			value:        value,
+			ttl:          a.TTL,
+			comment:      a.Comment,
+			created:      created,
+			createdBy:    c.user,
+			modified:     created,
+			modifiedBy:   c.user,
+		})
+	}
+	return nil, nil
+}
+
+func (s *Server) rrGetAttrs(c call) (any, *Error) {
+	var a rrArgs
+	if err := c.args(&a); err != nil {
+		return nil, err
+	}
+	r, err := s.findOne(a)
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]any{
+		"rr":          fmt.Sprintf("%s %s %s", r.name, r.typ, r.value),
+		"zone":        r.zone,
+		"created":     r.created,
+		"created_by":  r.createdBy,
+		"modified":    r.modified,
+		"modified_by": r.modifiedBy,
+	}
+	if r.ttl != nil {
+		res["TTL"] = *r.ttl
+	}
+	if r.comment != nil {
+		res["comment"] = *r.comment
+	}
+	return res, nil
+}
+
+func (s *Server) rrSetAttrs(c call) (any, *Error) {
+	var a rrArgs
+	if err := c.args(&a); err != nil {
+		return nil, err
+	}
+	r, err := s.findOne(a)
+	if err != nil {
+		return nil, err
+	}
+	if a.TTL != nil {
+		r.ttl = a.TTL
+	}
+	if a.Comment != nil {
+		r.comment = a.Comment
+	}
+	r.modified = now()
+	r.modifiedBy = c.user
+	return nil, nil
+}
+
+func (s *Server) rrDelete(c call) (any, *Error) {
+	var a rrArgs
+	if err := c.args(&a); err != nil {
+		return nil, err
+	}
+	found, err := s.find(a)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, errorf(CodeDimError, "No %s RR found for %s", a.Type, a.Name)
+	}
+	rrs := s.rrs[:0]
+	for _, r := range s.rrs {
+		deleted := false
+		for _, f := range found {
+			deleted = deleted || r == f
+		}
+		if !deleted {
+			rrs = append(rrs, r)
+		}
+	}
+	s.rrs = rrs
+	return nil, nil
+}
+
+func (s *Server) rrList(c call) (any, *Error) {
+	var opts rrListOptions
+	if err := c.args(&opts); err != nil {
+		return nil, err
+	}
+	if opts.Zone != "" {
+		if _, ok := s.zones[opts.Zone]; !ok {
+			return nil, errorf(CodeInvalidZone, "Zone %s does not exist", opts.Zone)
+		}
+	}
+
+	var found []*rr
+	for _, r := range s.rrs {
+		if opts.Type != "" && r.typ != opts.Type ||
+			opts.Zone != "" && r.zone != opts.Zone ||
+			opts.View != "" && r.view != opts.View ||
+			opts.Layer3domain != "" && r.layer3domain != opts.Layer3domain ||
+			opts.CreatedBy != "" && r.createdBy != opts.CreatedBy ||
+			opts.Comment != "" && (r.comment == nil || *r.comment != opts.Comment) {
+			continue
+		}
+		if opts.Pattern != "" {
+			fqdn := r.zone + "."
+			if r.name != "@" {
+				fqdn = r.name + "." + fqdn
+			}
+			matchName, err := path.Match(opts.Pattern, r.name)
+			if err != nil {
+				return nil, errorf(CodeInvalidParameter, "Invalid pattern %q", opts.Pattern)
+			}
+			matchFqdn, _ := path.Match(opts.Pattern, fqdn)
+			if !matchName && !matchFqdn {
+				continue
+			}
+		}
+		found = append(found, r)
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		a, b := found[i], found[j]
+		if a.zone != b.zone {
+			return a.zone < b.zone
+		}
+		if a.view != b.view {
+			return a.view < b.view
+		}
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		if a.typ != b.typ {
+			return a.typ < b.typ
+		}
+		return a.value < b.value
+	})
+
+	res := []map[string]any{}
+	for _, r := range found {
+		item := map[string]any{
+			"record":       r.name,
+			"type":         r.typ,
+			"value":        r.value,
+			"ttl":          r.ttl,
+			"zone":         r.zone,
+			"view":         r.view,
+			"layer3domain": r.layer3domain,
+		}
+		if opts.Fields {
+			item["comment"] = ""
+			if r.comment != nil {
+				item["comment"] = *r.comment
+			}
+			item["created"] = r.created
+			item["created_by"] = r.createdBy
+			item["modified"] = r.modified
+			item["modified_by"] = r.modifiedBy
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
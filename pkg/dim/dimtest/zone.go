@@ -0,0 +1,406 @@
+package dimtest
+
+// the SOA attributes of the zones created by zone_create
+const (
+	defaultPrimary = "ns.example.com."
+	defaultMail    = "hostmaster.example.com."
+	defaultRefresh = 10800
+	defaultRetry   = 3600
+	defaultExpire  = 604800
+	defaultMinimum = 86400
+	defaultTTL     = 86400
+)
+
+type zone struct {
+	name  string
+	views []string
+	soa   map[string]*soa // view -> SOA attributes
+
+	comment    *string
+	created    string
+	createdBy  string
+	modified   string
+	modifiedBy string
+}
+
+// soa are the SOA attributes of a zone view
+type soa struct {
+	Primary string `json:"primary"`
+	Mail    string `json:"mail"`
+	Serial  int64  `json:"serial"`
+	Refresh int64  `json:"refresh"`
+	Retry   int64  `json:"retry"`
+	Expire  int64  `json:"expire"`
+	Minimum int64  `json:"minimum"`
+	TTL     int64  `json:"ttl"`
+}
+
+// soaArgs are the SOA attributes of zone_create and zone_set_soa_attrs,
+// the attributes left unset are not changed
+type soaArgs struct {
+	Primary *string `json:"primary"`
+	Mail    *string `json:"mail"`
+	Refresh *int64  `json:"refresh"`
+	Retry   *int64  `json:"retry"`
+	Expire  *int64  `json:"expire"`
+	Minimum *int64  `json:"minimum"`
+	TTL     *int64  `json:"ttl"`
+}
+
+// zoneOptions are the options of zone_get_attrs and zone_set_attrs
+type zoneOptions struct {
+	Profile bool `json:"profile"`
+}
+
+// zoneViewOptions are the options of zone_get_soa_attrs and zone_set_soa_attrs
+type zoneViewOptions struct {
+	Profile bool   `json:"profile"`
+	View    string `json:"view"`
+}
+
+type zoneCreateOptions struct {
+	Profile       bool              `json:"profile"`
+	FromProfile   string            `json:"from_profile"`
+	SOAAttributes *soaArgs          `json:"soa_attributes"`
+	Attributes    map[string]string `json:"attributes"`
+}
+
+type zoneDeleteOptions struct {
+	Profile bool `json:"profile"`
+	Cleanup bool `json:"cleanup"`
+}
+
+type zoneViewCreateOptions struct {
+	FromProfile string `json:"from_profile"`
+}
+
+type zoneViewDeleteOptions struct {
+	Cleanup bool `json:"cleanup"`
+}
+
+// apply sets the attributes of a in o and increments the serial
+func (a soaArgs) apply(o *soa) {
+	if a.Primary != nil {
+		o.Primary = *a.Primary
+	}
+	if a.Mail != nil {
+		o.Mail = *a.Mail
+	}
+	if a.Refresh != nil {
+		o.Refresh = *a.Refresh
+	}
+	if a.Retry != nil {
+		o.Retry = *a.Retry
+	}
+	if a.Expire != nil {
+		o.Expire = *a.Expire
+	}
+	if a.Minimum != nil {
+		o.Minimum = *a.Minimum
+	}
+	if a.TTL != nil {
+		o.TTL = *a.TTL
+	}
+	o.Serial++
+}
+
+// zone returns the zone, zone profiles are not supported by the Server
+func (s *Server) zone(name string, profile bool) (*zone, *Error) {
+	if profile {
+		return nil, errorf(CodeInvalidParameter, "Zone profiles are not supported")
+	}
+	z, ok := s.zones[name]
+	if !ok {
+		return nil, errorf(CodeInvalidZone, "Zone %s does not exist", name)
+	}
+	return z, nil
+}
+
+// view returns the view of the zone, the only one if view is empty
+func (z *zone) view(view string) (string, *Error) {
+	if view == "" {
+		if len(z.views) != 1 {
+			return "", errorf(CodeInvalidView, "Zone %s has multiple views, specify the view", z.name)
+		}
+		return z.views[0], nil
+	}
+	for _, v := range z.views {
+		if v == view {
+			return v, nil
+		}
+	}
+	return "", errorf(CodeInvalidView, "View %s of zone %s does not exist", view, z.name)
+}
+
+// soaOf returns the SOA attributes of the view, the default ones for the zones added by AddZone
+func (z *zone) soaOf(view string) *soa {
+	if z.soa == nil {
+		z.soa = map[string]*soa{}
+	}
+	if z.soa[view] == nil {
+		z.soa[view] = &soa{
+			Primary: defaultPrimary,
+			Mail:    defaultMail,
+			Serial:  1,
+			Refresh: defaultRefresh,
+			Retry:   defaultRetry,
+			Expire:  defaultExpire,
+			Minimum: defaultMinimum,
+			TTL:     defaultTTL,
+		}
+	}
+	return z.soa[view]
+}
+
+func (z *zone) touch(user string) {
+	z.modified, z.modifiedBy = now(), user
+}
+
+// hasRecords reports whether the zone has records in the view, in any view if view is empty
+func (s *Server) hasRecords(zone, view string) bool {
+	for _, r := range s.rrs {
+		if r.zone == zone && (view == "" || r.view == view) {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteRecords deletes the records of the zone in the view, in all views if view is empty
+func (s *Server) deleteRecords(zone, view string) {
+	rrs := s.rrs[:0]
+	for _, r := range s.rrs {
+		if r.zone != zone || view != "" && r.view != view {
+			rrs = append(rrs, r)
+		}
+	}
+	s.rrs = rrs
+}
+
+// zoneCreate creates the zone with DefaultView
+func (s *Server) zoneCreate(c call) (any, *Error) {
+	var name string
+	var opts zoneCreateOptions
+	if err := c.args(&name, &opts); err != nil {
+		return nil, err
+	}
+	if opts.Profile || opts.FromProfile != "" {
+		return nil, errorf(CodeInvalidParameter, "Zone profiles are not supported")
+	}
+	if _, ok := s.zones[name]; ok {
+		return nil, errorf(CodeAlreadyExists, "Zone %s already exists", name)
+	}
+	z := &zone{name: name, views: []string{DefaultView}, createdBy: c.user}
+	z.touch(c.user)
+	z.created = z.modified
+	if opts.SOAAttributes != nil {
+		opts.SOAAttributes.apply(z.soaOf(DefaultView))
+	}
+	if comment, ok := opts.Attributes["comment"]; ok {
+		z.comment = &comment
+	}
+	s.zones[name] = z
+	return nil, nil
+}
+
+func (s *Server) zoneGetAttrs(c call) (any, *Error) {
+	var name string
+	var opts zoneOptions
+	if err := c.args(&name, &opts); err != nil {
+		return nil, err
+	}
+	z, err := s.zone(name, opts.Profile)
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]any{
+		"name":        z.name,
+		"created":     z.created,
+		"created_by":  z.createdBy,
+		"modified":    z.modified,
+		"modified_by": z.modifiedBy,
+	}
+	if z.comment != nil {
+		res["comment"] = *z.comment
+	}
+	return res, nil
+}
+
+// zoneSetAttrs sets the comment of the zone, the only attribute kept by the Server
+func (s *Server) zoneSetAttrs(c call) (any, *Error) {
+	var name string
+	var attrs map[string]string
+	var opts zoneOptions
+	if err := c.args(&name, &attrs, &opts); err != nil {
+		return nil, err
+	}
+	z, err := s.zone(name, opts.Profile)
+	if err != nil {
+		return nil, err
+	}
+	if comment, ok := attrs["comment"]; ok {
+		z.comment = &comment
+	}
+	z.touch(c.user)
+	return nil, nil
+}
+
+func (s *Server) zoneGetSOAAttrs(c call) (any, *Error) {
+	var name string
+	var opts zoneViewOptions
+	if err := c.args(&name, &opts); err != nil {
+		return nil, err
+	}
+	z, err := s.zone(name, opts.Profile)
+	if err != nil {
+		return nil, err
+	}
+	view, err := z.view(opts.View)
+	if err != nil {
+		return nil, err
+	}
+	return z.soaOf(view), nil
+}
+
+func (s *Server) zoneSetSOAAttrs(c call) (any, *Error) {
+	var name string
+	var attrs soaArgs
+	var opts zoneViewOptions
+	if err := c.args(&name, &attrs, &opts); err != nil {
+		return nil, err
+	}
+	z, err := s.zone(name, opts.Profile)
+	if err != nil {
+		return nil, err
+	}
+	view, err := z.view(opts.View)
+	if err != nil {
+		return nil, err
+	}
+	attrs.apply(z.soaOf(view))
+	z.touch(c.user)
+	return nil, nil
+}
+
+// zoneDelete deletes the zone, its records only if cleanup is set
+func (s *Server) zoneDelete(c call) (any, *Error) {
+	var name string
+	var opts zoneDeleteOptions
+	if err := c.args(&name, &opts); err != nil {
+		return nil, err
+	}
+	z, err := s.zone(name, opts.Profile)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.Cleanup && s.hasRecords(z.name, "") {
+		return nil, errorf(CodeDimError, "Zone %s is not empty", z.name)
+	}
+	s.deleteRecords(z.name, "")
+	delete(s.zones, z.name)
+	return nil, nil
+}
+
+func (s *Server) zoneListViews(c call) (any, *Error) {
+	var name string
+	if err := c.args(&name); err != nil {
+		return nil, err
+	}
+	z, err := s.zone(name, false)
+	if err != nil {
+		return nil, err
+	}
+	res := []map[string]any{}
+	for _, v := range z.views {
+		res = append(res, map[string]any{"name": v})
+	}
+	return res, nil
+}
+
+func (s *Server) zoneCreateView(c call) (any, *Error) {
+	var name, view string
+	var opts zoneViewCreateOptions
+	if err := c.args(&name, &view, &opts); err != nil {
+		return nil, err
+	}
+	if opts.FromProfile != "" {
+		return nil, errorf(CodeInvalidParameter, "Zone profiles are not supported")
+	}
+	z, err := s.zone(name, false)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := z.view(view); err == nil {
+		return nil, errorf(CodeAlreadyExists, "View %s of zone %s already exists", view, z.name)
+	}
+	z.views = append(z.views, view)
+	z.touch(c.user)
+	return nil, nil
+}
+
+// zoneRenameView renames the view of the zone and of its records
+func (s *Server) zoneRenameView(c call) (any, *Error) {
+	var name, view, newName string
+	if err := c.args(&name, &view, &newName); err != nil {
+		return nil, err
+	}
+	z, err := s.zone(name, false)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := z.view(view); err != nil {
+		return nil, err
+	}
+	if _, err := z.view(newName); err == nil {
+		return nil, errorf(CodeAlreadyExists, "View %s of zone %s already exists", newName, z.name)
+	}
+	for i, v := range z.views {
+		if v == view {
+			z.views[i] = newName
+		}
+	}
+	if o, ok := z.soa[view]; ok {
+		z.soa[newName] = o
+		delete(z.soa, view)
+	}
+	for _, r := range s.rrs {
+		if r.zone == z.name && r.view == view {
+			r.view = newName
+		}
+	}
+	z.touch(c.user)
+	return nil, nil
+}
+
+// zoneDeleteView deletes the view of the zone, its records only if cleanup is set
+func (s *Server) zoneDeleteView(c call) (any, *Error) {
+	var name, view string
+	var opts zoneViewDeleteOptions
+	if err := c.args(&name, &view, &opts); err != nil {
+		return nil, err
+	}
+	z, err := s.zone(name, false)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := z.view(view); err != nil {
+		return nil, err
+	}
+	if len(z.views) == 1 {
+		return nil, errorf(CodeDimError, "The last view of zone %s can not be deleted", z.name)
+	}
+	if !opts.Cleanup && s.hasRecords(z.name, view) {
+		return nil, errorf(CodeDimError, "View %s of zone %s is not empty", view, z.name)
+	}
+	s.deleteRecords(z.name, view)
+	views := z.views[:0]
+	for _, v := range z.views {
+		if v != view {
+			views = append(views, v)
+		}
+	}
+	z.views = views
+	delete(z.soa, view)
+	z.touch(c.user)
+	return nil, nil
+}
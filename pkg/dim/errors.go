@@ -11,3 +11,13 @@ type Error struct {
 func (e Error) Error() string {
 	return fmt.Sprintf("%s error (%d): %s", e.Func, e.Code, e.Message)
 }
+
+// statusError is returned for the http responses other than 200 OK
+type statusError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+}
@@ -0,0 +1,166 @@
+package dim
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// IPBlockOptions are the options selecting an ip block,
+// accepted by ipblock_get_attrs, ipblock_set_attrs and ip_free
+type IPBlockOptions struct {
+	Host         bool   `json:"host,omitempty"`
+	Layer3domain string `json:"layer3domain,omitempty"`
+	Pool         string `json:"pool,omitempty"`
+}
+
+// IPBlockRemoveOptions are the options of ipblock_remove
+type IPBlockRemoveOptions struct {
+	IPBlockOptions
+	Status    string `json:"status,omitempty"` // remove the block only if it has this status
+	Force     bool   `json:"force,omitempty"`
+	Recursive bool   `json:"recursive,omitempty"`
+}
+
+// IPBlockCreateOptions are the options of ipblock_create
+type IPBlockCreateOptions struct {
+	Status       string            `json:"status,omitempty"` // DIM defaults to "Container"
+	Layer3domain string            `json:"layer3domain,omitempty"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+}
+
+// IPMarkOptions are the options of ip_mark
+type IPMarkOptions struct {
+	IPBlockOptions
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// IPPoolGetIPOptions are the options of ippool_get_ip
+type IPPoolGetIPOptions struct {
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// IPBlockAttrs is the result of ipblock_get_attrs,
+// ip_mark and ippool_get_ip.
+// See the example response in ../../docs/.dim/ipblock_get_attrs.md
+type IPBlockAttrs struct {
+	IP           string `json:"ip"`
+	Layer3domain string `json:"layer3domain"`
+	Created      string `json:"created"` // no created_by field!
+	Modified     string `json:"modified"`
+	ModifiedBy   string `json:"modified_by"`
+	Gateway      string `json:"gateway"`
+	Mask         string `json:"mask"`
+	Pool         string `json:"pool"`
+	ReverseZone  string `json:"reverse_zone"`
+	Status       string `json:"status"`
+	Subnet       string `json:"subnet"`
+
+	Comment *string `json:"comment"`
+
+	// Attributes are the user defined attributes of the ip block
+	Attributes map[string]string `json:"-"`
+}
+
+// UnmarshalJSON collects the user defined attributes into Attributes
+func (a *IPBlockAttrs) UnmarshalJSON(data []byte) error {
+	type ipBlockAttrsAlias IPBlockAttrs // has no UnmarshalJSON method
+	if err := json.Unmarshal(data, (*ipBlockAttrsAlias)(a)); err != nil {
+		return err
+	}
+	attrs, err := customAttrs(data, ipBlockAttrsAlias{})
+	if err != nil {
+		return err
+	}
+	a.Attributes = attrs
+	return nil
+}
+
+// IPPoolGetIP allocates the next available ip address from the pool
+func (c *Client) IPPoolGetIP(ctx context.Context, pool string, opts IPPoolGetIPOptions) (*IPBlockAttrs, error) {
+	var res IPBlockAttrs
+	if err := c.callWithContext(ctx, "ippool_get_ip", []any{pool, opts}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// IPMark marks the ip address as Static
+func (c *Client) IPMark(ctx context.Context, ip string, opts IPMarkOptions) (*IPBlockAttrs, error) {
+	var res IPBlockAttrs
+	if err := c.callWithContext(ctx, "ip_mark", []any{ip, opts}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// IPFree frees the ip address. The result is
+//   - 1 if the address has been freed
+//   - 0 if the address was already free
+//   - -1 if the address is reserved
+func (c *Client) IPFree(ctx context.Context, ip string, opts IPBlockOptions) (int, error) {
+	var res int
+	if err := c.callWithContext(ctx, "ip_free", []any{ip, opts}, &res); err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+// IPBlockGetAttrs returns the attributes of the ip block
+func (c *Client) IPBlockGetAttrs(ctx context.Context, ip string, opts IPBlockOptions) (*IPBlockAttrs, error) {
+	var res IPBlockAttrs
+	if err := c.callWithContext(ctx, "ipblock_get_attrs", []any{ip, opts}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// IPBlockSetAttrs sets the attributes of the ip block
+func (c *Client) IPBlockSetAttrs(ctx context.Context, ip string, attrs map[string]string, opts IPBlockOptions) error {
+	return c.callWithContext(ctx, "ipblock_set_attrs", []any{ip, attrs, opts}, nil)
+}
+
+// IPBlockRemove removes the ip block
+func (c *Client) IPBlockRemove(ctx context.Context, block string, opts IPBlockRemoveOptions) error {
+	return c.callWithContext(ctx, "ipblock_remove", []any{block, opts}, nil)
+}
+
+// IPBlockCreate creates the ip block
+func (c *Client) IPBlockCreate(ctx context.Context, block string, opts IPBlockCreateOptions) error {
+	return c.callWithContext(ctx, "ipblock_create", []any{block, opts}, nil)
+}
+
+// IPBlockDeleteAttrs deletes the attributes of the ip block
+func (c *Client) IPBlockDeleteAttrs(ctx context.Context, ip string, names []string, opts IPBlockOptions) error {
+	return c.callWithContext(ctx, "ipblock_delete_attrs", []any{ip, names, opts}, nil)
+}
+
+// IPListOptions are the options of ip_list
+type IPListOptions struct {
+	Pool         string `json:"pool,omitempty"`
+	Type         string `json:"type,omitempty"` // one of "all", "used", "free", "subnets"
+	Layer3domain string `json:"layer3domain,omitempty"`
+	Full         bool   `json:"full,omitempty"`
+	Limit        int64  `json:"limit,omitempty"`
+}
+
+// IPListItem is an element of the ip_list result,
+// the address counts are returned for the subnets only
+type IPListItem struct {
+	IP      string `json:"ip"`
+	Status  string `json:"status"`
+	Gateway string `json:"gateway"`
+	Mask    string `json:"mask"`
+	Total   *int64 `json:"total"`
+	Free    *int64 `json:"free"`
+
+	Comment *string `json:"comment"`
+}
+
+// IPList lists the ip blocks of the pool
+func (c *Client) IPList(ctx context.Context, opts IPListOptions) ([]IPListItem, error) {
+	var res []IPListItem
+	if err := c.callWithContext(ctx, "ip_list", []any{opts}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,115 @@
+package dim
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// IPPoolCreateOptions are the options of ippool_create
+type IPPoolCreateOptions struct {
+	VLAN         *int64            `json:"vlan,omitempty"`
+	Layer3domain string            `json:"layer3domain,omitempty"`
+	Attributes   map[string]string `json:"attributes,omitempty"` // including "description"
+}
+
+// IPPoolDeleteOptions are the options of ippool_delete
+type IPPoolDeleteOptions struct {
+	Force         bool `json:"force,omitempty"`
+	DeleteSubnets bool `json:"delete_subnets,omitempty"`
+}
+
+// IPPoolAddSubnetOptions are the options of ippool_add_subnet
+type IPPoolAddSubnetOptions struct {
+	Gateway                     string            `json:"gateway,omitempty"`
+	DontReserveNetworkBroadcast bool              `json:"dont_reserve_network_broadcast,omitempty"`
+	Attributes                  map[string]string `json:"attributes,omitempty"`
+}
+
+// IPPoolAttrs is the result of ippool_get_attrs
+type IPPoolAttrs struct {
+	Name         string `json:"name"`
+	VLAN         *int64 `json:"vlan"`
+	Layer3domain string `json:"layer3domain"`
+	Created      string `json:"created"`
+	Modified     string `json:"modified"`
+	ModifiedBy   string `json:"modified_by"`
+
+	Description *string `json:"description"`
+
+	// Attributes are the user defined attributes of the pool
+	Attributes map[string]string `json:"-"`
+}
+
+// UnmarshalJSON collects the user defined attributes into Attributes
+func (a *IPPoolAttrs) UnmarshalJSON(data []byte) error {
+	type ipPoolAttrsAlias IPPoolAttrs // has no UnmarshalJSON method
+	if err := json.Unmarshal(data, (*ipPoolAttrsAlias)(a)); err != nil {
+		return err
+	}
+	attrs, err := customAttrs(data, ipPoolAttrsAlias{})
+	if err != nil {
+		return err
+	}
+	a.Attributes = attrs
+	return nil
+}
+
+// IPPoolCreate creates the pool
+func (c *Client) IPPoolCreate(ctx context.Context, pool string, opts IPPoolCreateOptions) error {
+	return c.callWithContext(ctx, "ippool_create", []any{pool, opts}, nil)
+}
+
+// IPPoolGetAttrs returns the attributes of the pool
+func (c *Client) IPPoolGetAttrs(ctx context.Context, pool string) (*IPPoolAttrs, error) {
+	var res IPPoolAttrs
+	if err := c.callWithContext(ctx, "ippool_get_attrs", []any{pool}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// IPPoolSetAttrs sets the attributes of the pool
+func (c *Client) IPPoolSetAttrs(ctx context.Context, pool string, attrs map[string]string) error {
+	return c.callWithContext(ctx, "ippool_set_attrs", []any{pool, attrs}, nil)
+}
+
+// IPPoolDeleteAttrs deletes the attributes of the pool
+func (c *Client) IPPoolDeleteAttrs(ctx context.Context, pool string, names []string) error {
+	return c.callWithContext(ctx, "ippool_delete_attrs", []any{pool, names}, nil)
+}
+
+// IPPoolSetVLAN sets the vlan of the pool
+func (c *Client) IPPoolSetVLAN(ctx context.Context, pool string, vlan int64) error {
+	return c.callWithContext(ctx, "ippool_set_vlan", []any{pool, vlan}, nil)
+}
+
+// IPPoolRemoveVLAN removes the vlan of the pool
+func (c *Client) IPPoolRemoveVLAN(ctx context.Context, pool string) error {
+	return c.callWithContext(ctx, "ippool_remove_vlan", []any{pool}, nil)
+}
+
+// IPPoolDelete deletes the pool
+func (c *Client) IPPoolDelete(ctx context.Context, pool string, opts IPPoolDeleteOptions) error {
+	return c.callWithContext(ctx, "ippool_delete", []any{pool, opts}, nil)
+}
+
+// IPPoolAddSubnet adds the subnet to the pool
+func (c *Client) IPPoolAddSubnet(ctx context.Context, pool, subnet string, opts IPPoolAddSubnetOptions) error {
+	return c.callWithContext(ctx, "ippool_add_subnet", []any{pool, subnet, opts}, nil)
+}
+
+// IPPoolGetDelegationOptions are the options of ippool_get_delegation
+type IPPoolGetDelegationOptions struct {
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Maxsplit   int64             `json:"maxsplit,omitempty"` // the delegation may be split into up to 2^maxsplit blocks
+}
+
+// IPPoolGetDelegation allocates the next available block of the given prefix length from the pool,
+// the result is the list of the allocated blocks
+func (c *Client) IPPoolGetDelegation(ctx context.Context, pool string, prefix int64, opts IPPoolGetDelegationOptions) ([]IPBlockAttrs, error) {
+	var res []IPBlockAttrs
+	if err := c.callWithContext(ctx, "ippool_get_delegation", []any{pool, prefix, opts}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,46 @@
+package dim
+
+import "context"
+
+// Layer3domainCreateOptions are the options of layer3domain_create
+type Layer3domainCreateOptions struct {
+	Comment string `json:"comment,omitempty"`
+	RD      string `json:"rd,omitempty"` // the route distinguisher, required by the "vrf" type
+}
+
+// Layer3domain is an element of the layer3domain_list result
+type Layer3domain struct {
+	Name    string  `json:"name"`
+	Type    string  `json:"type"`
+	RD      string  `json:"rd"`
+	Comment *string `json:"comment"`
+}
+
+// Layer3domainCreate creates the layer3domain of the type, e.g. "vrf"
+func (c *Client) Layer3domainCreate(ctx context.Context, name, layer3domainType string, opts Layer3domainCreateOptions) error {
+	return c.callWithContext(ctx, "layer3domain_create", []any{name, layer3domainType, opts}, nil)
+}
+
+// Layer3domainRename renames the layer3domain
+func (c *Client) Layer3domainRename(ctx context.Context, name, newName string) error {
+	return c.callWithContext(ctx, "layer3domain_rename", []any{name, newName}, nil)
+}
+
+// Layer3domainSetAttrs sets the attributes (e.g. "comment") of the layer3domain
+func (c *Client) Layer3domainSetAttrs(ctx context.Context, name string, attrs map[string]string) error {
+	return c.callWithContext(ctx, "layer3domain_set_attrs", []any{name, attrs}, nil)
+}
+
+// Layer3domainDelete deletes the layer3domain
+func (c *Client) Layer3domainDelete(ctx context.Context, name string) error {
+	return c.callWithContext(ctx, "layer3domain_delete", []any{name}, nil)
+}
+
+// Layer3domainList lists the layer3domains
+func (c *Client) Layer3domainList(ctx context.Context) ([]Layer3domain, error) {
+	var res []Layer3domain
+	if err := c.callWithContext(ctx, "layer3domain_list", []any{}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
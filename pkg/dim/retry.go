@@ -0,0 +1,100 @@
+package dim
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryWaitMin = 1 * time.Second
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// idempotentFuncs are the DIM functions which only read,
+// so they can be safely called again after a failure
+// of which it is unknown whether DIM has processed the call
+var idempotentFuncs = map[string]bool{
+	"rr_get_attrs":          true,
+	"rr_list":               true,
+	"ipblock_get_attrs":     true,
+	"ip_list":               true,
+	"ippool_get_attrs":      true,
+	"zone_get_attrs":        true,
+	"zone_get_soa_attrs":    true,
+	"zone_list_views":       true,
+	"zone_list_zone_groups": true,
+	"layer3domain_list":     true,
+}
+
+// WithRetry sets the maximum number of retries of a failed call
+// and the bounds of the exponential backoff between the retries.
+// Zero maxRetries disables the retries.
+func WithRetry(maxRetries int, waitMin, waitMax time.Duration) ClientOption {
+	return func(c *Client) error {
+		if maxRetries < 0 || waitMin < 0 || waitMin > waitMax {
+			return errors.New("invalid retry settings")
+		}
+		c.maxRetries = maxRetries
+		c.retryWaitMin = waitMin
+		c.retryWaitMax = waitMax
+		return nil
+	}
+}
+
+// retryable reports whether the call of the DIM function
+// that failed with err can be retried
+func retryable(function string, err error) bool {
+	// the request has not been sent, DIM may be restarting
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	if !idempotentFuncs[function] || errors.Is(err, ErrSessionExpired) {
+		return false
+	}
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	// DIM errors are returned from callWithContext without retry,
+	// anything else is a transport error
+	return true
+}
+
+// backoff returns the wait before the retry following the attempt (counted from 0),
+// i.e. retryWaitMin doubled with every attempt up to retryWaitMax,
+// of which a random half is subtracted to spread the retries of concurrent calls
+func (c *Client) backoff(attempt int) time.Duration {
+	wait := c.retryWaitMin
+	for i := 0; i < attempt && wait < c.retryWaitMax; i++ {
+		wait *= 2
+	}
+	if wait > c.retryWaitMax {
+		wait = c.retryWaitMax
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// sleepWithContext waits for d or until ctx is done
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
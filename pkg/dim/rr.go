@@ -0,0 +1,159 @@
+package dim
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// RR identifies a resource record.
+// rr_get_attrs and rr_set_attrs accept no zone, so for them
+// Name must be a fqdn with trailing dot and Zone must be empty.
+type RR struct {
+	Type         string `json:"type"`
+	Name         string `json:"name,omitempty"` // PTR records might be identified by IP instead
+	Zone         string `json:"zone,omitempty"`
+	View         string `json:"view,omitempty"` // rr_create has "views" plural as well, we always use a single view
+	Layer3domain string `json:"layer3domain,omitempty"`
+
+	// A, AAAA, PTR
+	IP string `json:"ip,omitempty"`
+	// CNAME
+	CNAME string `json:"cname,omitempty"`
+	// TXT
+	Strings []string `json:"strings,omitempty"`
+	// PTR
+	PTRDName string `json:"ptrdname,omitempty"`
+	// MX
+	Preference *int64 `json:"preference,omitempty"` // 0 is a valid preference
+	Exchange   string `json:"exchange,omitempty"`
+	// SRV
+	Priority *int64 `json:"priority,omitempty"`
+	Weight   *int64 `json:"weight,omitempty"`
+	Port     *int64 `json:"port,omitempty"`
+	Target   string `json:"target,omitempty"`
+
+	// Fields holds the type specific fields not modelled above,
+	// e.g. "algorithm", "fingerprint_type" and "fingerprint" of a SSHFP record
+	Fields map[string]any `json:"-"`
+}
+
+// MarshalJSON adds Fields to the RR object
+func (rr RR) MarshalJSON() ([]byte, error) {
+	type rrAlias RR // has no MarshalJSON method
+	if len(rr.Fields) == 0 {
+		return json.Marshal(rrAlias(rr))
+	}
+	args, err := mergeArgs(rrAlias(rr), rr.Fields)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(args)
+}
+
+// RRCreateOptions are the options of rr_create
+type RRCreateOptions struct {
+	TTL       *int64  `json:"ttl,omitempty"`
+	Comment   *string `json:"comment,omitempty"`
+	Overwrite bool    `json:"overwrite,omitempty"`
+}
+
+// RRSetAttrsOptions are the attributes changeable by rr_set_attrs
+type RRSetAttrsOptions struct {
+	TTL     *int64  `json:"ttl,omitempty"`
+	Comment *string `json:"comment,omitempty"`
+}
+
+// RRDeleteOptions are the options of rr_delete
+type RRDeleteOptions struct {
+	References string `json:"references,omitempty"` // one of "delete", "ignore", "warn"
+}
+
+// RRAttrs is the result of rr_get_attrs.
+// Not all attributes of a RR are returned,
+// see the example response in ../../docs/.dim/rr_get_attrs.md
+type RRAttrs struct {
+	RR         string  `json:"rr"`
+	Zone       string  `json:"zone"`
+	View       *string `json:"view"` // not confirmed, whether this attribute is returned
+	TTL        *int64  `json:"TTL"`
+	Comment    *string `json:"comment"`
+	Created    string  `json:"created"`
+	CreatedBy  string  `json:"created_by"`
+	Modified   string  `json:"modified"`
+	ModifiedBy string  `json:"modified_by"`
+}
+
+// RRListOptions are the filters of rr_list
+type RRListOptions struct {
+	Type         string `json:"type,omitempty"`
+	Pattern      string `json:"pattern,omitempty"`
+	Zone         string `json:"zone,omitempty"`
+	View         string `json:"view,omitempty"`
+	Layer3domain string `json:"layer3domain,omitempty"`
+	CreatedBy    string `json:"created_by,omitempty"`
+	Comment      string `json:"comment,omitempty"`
+	Fields       bool   `json:"fields,omitempty"`
+}
+
+// RRListItem is an element of the rr_list result
+type RRListItem struct {
+	Record       string `json:"record"`
+	Type         string `json:"type"`
+	Value        string `json:"value"`
+	TTL          *int64 `json:"ttl"`
+	Zone         string `json:"zone"`
+	View         string `json:"view"`
+	Layer3domain string `json:"layer3domain"`
+
+	// returned only if RRListOptions.Fields is set
+	Comment    string `json:"comment"`
+	Created    string `json:"created"`
+	CreatedBy  string `json:"created_by"`
+	Modified   string `json:"modified"`
+	ModifiedBy string `json:"modified_by"`
+}
+
+// RRCreate creates the resource record
+func (c *Client) RRCreate(ctx context.Context, rr RR, opts RRCreateOptions) error {
+	args, err := mergeArgs(rr, opts)
+	if err != nil {
+		return err
+	}
+	return c.callWithContext(ctx, "rr_create", []any{args}, nil)
+}
+
+// RRGetAttrs returns the attributes of the resource record
+func (c *Client) RRGetAttrs(ctx context.Context, rr RR) (*RRAttrs, error) {
+	var res RRAttrs
+	if err := c.callWithContext(ctx, "rr_get_attrs", []any{rr}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// RRSetAttrs changes the attributes of the resource record
+func (c *Client) RRSetAttrs(ctx context.Context, rr RR, opts RRSetAttrsOptions) error {
+	args, err := mergeArgs(rr, opts)
+	if err != nil {
+		return err
+	}
+	return c.callWithContext(ctx, "rr_set_attrs", []any{args}, nil)
+}
+
+// RRDelete deletes the resource record
+func (c *Client) RRDelete(ctx context.Context, rr RR, opts RRDeleteOptions) error {
+	args, err := mergeArgs(rr, opts)
+	if err != nil {
+		return err
+	}
+	return c.callWithContext(ctx, "rr_delete", []any{args}, nil)
+}
+
+// RRList lists the resource records matching the filters
+func (c *Client) RRList(ctx context.Context, opts RRListOptions) ([]RRListItem, error) {
+	var res []RRListItem
+	if err := c.callWithContext(ctx, "rr_list", []any{opts}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
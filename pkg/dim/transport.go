@@ -0,0 +1,90 @@
+package dim
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const DefaultTimeout = 10 * time.Second
+
+// TransportOptions are the settings of the http connection to DIM
+type TransportOptions struct {
+	// Timeout of a request, DefaultTimeout if zero
+	Timeout time.Duration
+	// CACertFile is the PEM bundle of the CA certificates trusted
+	// in addition to the system ones
+	CACertFile string
+	// ClientCertFile and ClientKeyFile are the PEM client certificate
+	// and its key for mutual TLS
+	ClientCertFile string
+	ClientKeyFile  string
+	// InsecureSkipVerify disables the verification of the DIM certificate
+	InsecureSkipVerify bool
+	// ProxyURL of the http proxy,
+	// the proxy environment variables are used if empty
+	ProxyURL string
+}
+
+// WithTransport configures the http connection to DIM
+func WithTransport(opts TransportOptions) ClientOption {
+	return func(c *Client) error {
+		httpClient, err := NewHTTPClient(opts)
+		if err != nil {
+			return err
+		}
+		c.httpClient = httpClient
+		return nil
+	}
+}
+
+// NewHTTPClient returns the http client configured by opts
+func NewHTTPClient(opts TransportOptions) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+
+	if opts.CACertFile != "" {
+		pem, err := os.ReadFile(opts.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read CA certificates, %s", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no CA certificates found in %s", opts.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if opts.ClientCertFile != "" || opts.ClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.ClientCertFile, opts.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate, %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url, %s", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
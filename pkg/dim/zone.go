@@ -0,0 +1,147 @@
+package dim
+
+import "context"
+
+// ZoneOptions are the options selecting a zone,
+// accepted by zone_get_attrs and zone_set_attrs
+type ZoneOptions struct {
+	Profile bool `json:"profile,omitempty"` // the zone is a zone profile
+}
+
+// ZoneViewOptions are the options selecting a view of a zone,
+// accepted by zone_get_soa_attrs and zone_set_soa_attrs.
+// View may be omitted if the zone has a single view.
+type ZoneViewOptions struct {
+	Profile bool   `json:"profile,omitempty"`
+	View    string `json:"view,omitempty"`
+}
+
+// ZoneSOAAttrs are the SOA attributes of a zone view
+type ZoneSOAAttrs struct {
+	Primary string `json:"primary,omitempty"`
+	Mail    string `json:"mail,omitempty"`
+	Serial  *int64 `json:"serial,omitempty"` // managed by DIM, not settable
+	Refresh *int64 `json:"refresh,omitempty"`
+	Retry   *int64 `json:"retry,omitempty"`
+	Expire  *int64 `json:"expire,omitempty"`
+	Minimum *int64 `json:"minimum,omitempty"`
+	TTL     *int64 `json:"ttl,omitempty"` // the default TTL of the RRs in the zone
+}
+
+// ZoneCreateOptions are the options of zone_create
+type ZoneCreateOptions struct {
+	Profile       bool              `json:"profile,omitempty"`
+	FromProfile   string            `json:"from_profile,omitempty"`
+	SOAAttributes *ZoneSOAAttrs     `json:"soa_attributes,omitempty"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+}
+
+// ZoneDeleteOptions are the options of zone_delete
+type ZoneDeleteOptions struct {
+	Profile bool `json:"profile,omitempty"`
+	Cleanup bool `json:"cleanup,omitempty"` // delete the RRs of the zone as well
+}
+
+// ZoneAttrs is the result of zone_get_attrs
+type ZoneAttrs struct {
+	Name       string `json:"name"`
+	Created    string `json:"created"`
+	CreatedBy  string `json:"created_by"`
+	Modified   string `json:"modified"`
+	ModifiedBy string `json:"modified_by"`
+
+	Comment *string `json:"comment"`
+}
+
+// ZoneCreate creates the zone
+func (c *Client) ZoneCreate(ctx context.Context, name string, opts ZoneCreateOptions) error {
+	return c.callWithContext(ctx, "zone_create", []any{name, opts}, nil)
+}
+
+// ZoneGetAttrs returns the attributes of the zone
+func (c *Client) ZoneGetAttrs(ctx context.Context, name string, opts ZoneOptions) (*ZoneAttrs, error) {
+	var res ZoneAttrs
+	if err := c.callWithContext(ctx, "zone_get_attrs", []any{name, opts}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// ZoneSetAttrs sets the attributes of the zone
+func (c *Client) ZoneSetAttrs(ctx context.Context, name string, attrs map[string]string, opts ZoneOptions) error {
+	return c.callWithContext(ctx, "zone_set_attrs", []any{name, attrs, opts}, nil)
+}
+
+// ZoneGetSOAAttrs returns the SOA attributes of the zone view
+func (c *Client) ZoneGetSOAAttrs(ctx context.Context, name string, opts ZoneViewOptions) (*ZoneSOAAttrs, error) {
+	var res ZoneSOAAttrs
+	if err := c.callWithContext(ctx, "zone_get_soa_attrs", []any{name, opts}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// ZoneSetSOAAttrs sets the SOA attributes of the zone view,
+// the attributes left unset are not changed
+func (c *Client) ZoneSetSOAAttrs(ctx context.Context, name string, attrs ZoneSOAAttrs, opts ZoneViewOptions) error {
+	return c.callWithContext(ctx, "zone_set_soa_attrs", []any{name, attrs, opts}, nil)
+}
+
+// ZoneDelete deletes the zone
+func (c *Client) ZoneDelete(ctx context.Context, name string, opts ZoneDeleteOptions) error {
+	return c.callWithContext(ctx, "zone_delete", []any{name, opts}, nil)
+}
+
+// ZoneViewCreateOptions are the options of zone_create_view
+type ZoneViewCreateOptions struct {
+	FromProfile string `json:"from_profile,omitempty"`
+}
+
+// ZoneViewDeleteOptions are the options of zone_delete_view
+type ZoneViewDeleteOptions struct {
+	Cleanup bool `json:"cleanup,omitempty"` // delete the references of the RRs of the view as well
+}
+
+// ZoneView is an element of the zone_list_views result
+type ZoneView struct {
+	Name string `json:"name"`
+}
+
+// ZoneCreateView creates the view of the zone
+func (c *Client) ZoneCreateView(ctx context.Context, zone, view string, opts ZoneViewCreateOptions) error {
+	return c.callWithContext(ctx, "zone_create_view", []any{zone, view, opts}, nil)
+}
+
+// ZoneRenameView renames the view of the zone
+func (c *Client) ZoneRenameView(ctx context.Context, zone, view, newName string) error {
+	return c.callWithContext(ctx, "zone_rename_view", []any{zone, view, newName}, nil)
+}
+
+// ZoneDeleteView deletes the view of the zone
+func (c *Client) ZoneDeleteView(ctx context.Context, zone, view string, opts ZoneViewDeleteOptions) error {
+	return c.callWithContext(ctx, "zone_delete_view", []any{zone, view, opts}, nil)
+}
+
+// ZoneListViews lists the views of the zone
+func (c *Client) ZoneListViews(ctx context.Context, zone string) ([]ZoneView, error) {
+	var res []ZoneView
+	if err := c.callWithContext(ctx, "zone_list_views", []any{zone}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ZoneGroupItem is an element of the zone_list_zone_groups result
+type ZoneGroupItem struct {
+	ZoneGroup string `json:"zone_group"`
+	View      string `json:"view"`
+}
+
+// ZoneListZoneGroups lists the zone groups the views of the zone belong to
+func (c *Client) ZoneListZoneGroups(ctx context.Context, zone string) ([]ZoneGroupItem, error) {
+	var res []ZoneGroupItem
+	if err := c.callWithContext(ctx, "zone_list_zone_groups", []any{zone}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}